@@ -0,0 +1,162 @@
+// Package admission turns the tri-state verdicts of admission webhook
+// validators into a single admission response.
+//
+// Each validator returns a Verdict whose Value is True to allow, False to
+// deny, or None for no opinion. Aggregate combines verdicts under a
+// Precedence, and Respond builds the response an AdmissionReview carries
+// back to the API server:
+//
+//	v := admission.Aggregate(admission.DenyOverrides, checkImage(req), checkLabels(req))
+//	review.Response = admission.Respond(req.UID, v, admission.AllowNoOpinion)
+//
+// Response has the JSON shape of admission.k8s.io/v1 AdmissionResponse, so
+// the package does not depend on k8s.io/api.
+package admission
+
+import (
+	"fmt"
+	"net/http"
+
+	"tristate"
+)
+
+// Verdict is one validator's decision. Name and Reason are reported back
+// to the user when the verdict decides the response.
+type Verdict struct {
+	Name   string
+	Value  tristate.TriState
+	Reason string
+}
+
+// Allow returns a verdict from name that admits the object.
+func Allow(name string) Verdict { return Verdict{Name: name, Value: tristate.New(true)} }
+
+// Deny returns a verdict from name that rejects the object for reason.
+func Deny(name, reason string) Verdict {
+	return Verdict{Name: name, Value: tristate.New(false), Reason: reason}
+}
+
+// NoOpinion returns a verdict from name that leaves the decision to the
+// other validators.
+func NoOpinion(name string) Verdict { return Verdict{Name: name} }
+
+// Denyf is Deny with a reason formatted by fmt.Sprintf.
+func Denyf(name, format string, a ...any) Verdict { return Deny(name, fmt.Sprintf(format, a...)) }
+
+// Precedence selects how Aggregate resolves conflicting verdicts.
+type Precedence uint8
+
+const (
+	// DenyOverrides denies if any validator denies, then allows if any
+	// allows.
+	DenyOverrides Precedence = iota
+	// AllowOverrides allows if any validator allows, then denies if any
+	// denies.
+	AllowOverrides
+	// FirstOpinion takes the first verdict that is not None, so
+	// validators are listed in priority order.
+	FirstOpinion
+)
+
+// String returns "deny-overrides", "allow-overrides", or "first-opinion".
+func (p Precedence) String() string {
+	switch p {
+	case DenyOverrides:
+		return "deny-overrides"
+	case AllowOverrides:
+		return "allow-overrides"
+	case FirstOpinion:
+		return "first-opinion"
+	default:
+		return fmt.Sprintf("Precedence(%d)", uint8(p))
+	}
+}
+
+// Aggregate combines vs under p and returns the deciding verdict: the
+// first deny for DenyOverrides, the first allow for AllowOverrides, or the
+// first opinion for FirstOpinion, falling back to the first verdict of the
+// other kind. If every verdict is None it returns a NoOpinion verdict with
+// an empty name.
+func Aggregate(p Precedence, vs ...Verdict) Verdict {
+	var combine func(...tristate.Policy) tristate.Policy
+	switch p {
+	case DenyOverrides:
+		combine = tristate.DenyOverrides
+	case AllowOverrides:
+		combine = tristate.AllowOverrides
+	case FirstOpinion:
+		for _, v := range vs {
+			if !v.Value.IsNone() {
+				return v
+			}
+		}
+		return Verdict{}
+	default:
+		panic(fmt.Sprintf("admission: unknown Precedence %d", p))
+	}
+	ps := make([]tristate.Policy, len(vs))
+	for i, v := range vs {
+		ps[i] = tristate.Policy(v.Value)
+	}
+	// The combinator picks the winning value; the deciding verdict is the
+	// first one that carries it.
+	if decided := combine(ps...).TriState(); !decided.IsNone() {
+		for _, v := range vs {
+			if v.Value == decided {
+				return v
+			}
+		}
+	}
+	return Verdict{}
+}
+
+// NoOpinionPolicy decides the response when the aggregate verdict is None.
+type NoOpinionPolicy bool
+
+const (
+	// AllowNoOpinion admits the object, as the API server does when no
+	// webhook objects.
+	AllowNoOpinion NoOpinionPolicy = true
+	// DenyNoOpinion rejects the object unless a validator allowed it.
+	DenyNoOpinion NoOpinionPolicy = false
+)
+
+// Response is the subset of an admission.k8s.io/v1 AdmissionResponse that
+// validating webhooks set.
+type Response struct {
+	UID     string  `json:"uid"`
+	Allowed bool    `json:"allowed"`
+	Result  *Status `json:"status,omitempty"`
+}
+
+// Status is the subset of a metav1.Status carried by a denial.
+type Status struct {
+	Code    int32  `json:"code"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Respond builds the Response to the review request uid for v. A denial
+// carries a 403 status whose message names the validator and its reason;
+// a None verdict is resolved by noOpinion.
+func Respond(uid string, v Verdict, noOpinion NoOpinionPolicy) Response {
+	allowed := v.Value.ValueOr(bool(noOpinion))
+	r := Response{UID: uid, Allowed: allowed}
+	if !allowed {
+		r.Result = &Status{Code: http.StatusForbidden, Reason: "Forbidden", Message: message(v)}
+	}
+	return r
+}
+
+func message(v Verdict) string {
+	switch {
+	case v.Value.IsNone():
+		return "no validator allowed the request"
+	case v.Name == "":
+		return v.Reason
+	case v.Reason == "":
+		return v.Name + " denied the request"
+	default:
+		return v.Name + ": " + v.Reason
+	}
+}
@@ -0,0 +1,64 @@
+package admission
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	image := Denyf("image", "registry %q is not trusted", "evil.io")
+	labels := Allow("labels")
+	quota := NoOpinion("quota")
+	owner := Deny("owner", "")
+
+	tests := []struct {
+		name string
+		p    Precedence
+		vs   []Verdict
+		want Verdict
+	}{
+		{"Deny overrides", DenyOverrides, []Verdict{labels, quota, image, owner}, image},
+		{"Deny overrides allows", DenyOverrides, []Verdict{quota, labels}, labels},
+		{"Allow overrides", AllowOverrides, []Verdict{image, quota, labels}, labels},
+		{"Allow overrides denies", AllowOverrides, []Verdict{owner, image}, owner},
+		{"First opinion", FirstOpinion, []Verdict{quota, labels, image}, labels},
+		{"No opinion", DenyOverrides, []Verdict{quota}, Verdict{}},
+		{"Empty", FirstOpinion, nil, Verdict{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Aggregate(tt.p, tt.vs...); got != tt.want {
+				t.Errorf("Aggregate(%v) = %+v, want %+v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespond(t *testing.T) {
+	tests := []struct {
+		name      string
+		v         Verdict
+		noOpinion NoOpinionPolicy
+		want      string
+	}{
+		{"Allow", Allow("labels"), DenyNoOpinion, `{"uid":"42","allowed":true}`},
+		{"Deny", Deny("image", "untrusted registry"), AllowNoOpinion,
+			`{"uid":"42","allowed":false,"status":{"code":403,"reason":"Forbidden","message":"image: untrusted registry"}}`},
+		{"Deny without reason", Deny("owner", ""), AllowNoOpinion,
+			`{"uid":"42","allowed":false,"status":{"code":403,"reason":"Forbidden","message":"owner denied the request"}}`},
+		{"No opinion allowed", Verdict{}, AllowNoOpinion, `{"uid":"42","allowed":true}`},
+		{"No opinion denied", Verdict{}, DenyNoOpinion,
+			`{"uid":"42","allowed":false,"status":{"code":403,"reason":"Forbidden","message":"no validator allowed the request"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(Respond("42", tt.v, tt.noOpinion))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Respond() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
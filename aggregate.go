@@ -0,0 +1,143 @@
+package tristate
+
+import (
+	"iter"
+	"slices"
+)
+
+// --- Aggregation ---
+
+// Count returns how many values are True, False, and None.
+func Count(vals []TriState) (trueN, falseN, noneN int) {
+	return countSeq(slices.Values(vals))
+}
+
+func countSeq(seq iter.Seq[TriState]) (trueN, falseN, noneN int) {
+	for v := range seq {
+		switch v.value {
+		case True:
+			trueN++
+		case False:
+			falseN++
+		default:
+			noneN++
+		}
+	}
+	return trueN, falseN, noneN
+}
+
+// All returns the Kleene conjunction of vals: False if any value is False,
+// otherwise None if any value is None, otherwise True. All of an empty
+// list is True.
+func All(vals []TriState) TriState {
+	out := New(true)
+	for _, v := range vals {
+		switch v.value {
+		case False:
+			return v
+		case None:
+			out = v
+		}
+	}
+	return out
+}
+
+// Any returns the Kleene disjunction of vals: True if any value is True,
+// otherwise None if any value is None, otherwise False. Any of an empty
+// list is False.
+func Any(vals []TriState) TriState {
+	out := New(false)
+	for _, v := range vals {
+		switch v.value {
+		case True:
+			return v
+		case None:
+			out = v
+		}
+	}
+	return out
+}
+
+// SliceAll is All for a packed Slice. It scans a whole word at a time.
+func SliceAll(s *Slice) TriState {
+	_, falseN, noneN := s.Counts()
+	return conjunction(falseN, noneN)
+}
+
+// SliceAny is Any for a packed Slice. It scans a whole word at a time.
+func SliceAny(s *Slice) TriState {
+	trueN, _, noneN := s.Counts()
+	return disjunction(trueN, noneN)
+}
+
+// conjunction returns the Kleene AND of a collection from its tallies.
+func conjunction(falseN, noneN int) TriState {
+	switch {
+	case falseN > 0:
+		return New(false)
+	case noneN > 0:
+		return TriState{}
+	default:
+		return New(true)
+	}
+}
+
+// disjunction returns the Kleene OR of a collection from its tallies.
+func disjunction(trueN, noneN int) TriState {
+	switch {
+	case trueN > 0:
+		return New(true)
+	case noneN > 0:
+		return TriState{}
+	default:
+		return New(false)
+	}
+}
+
+// --- Streaming ---
+
+// Aggregator accumulates TriState values one at a time and reports running
+// aggregates without buffering the stream. The zero value is an empty
+// Aggregator ready to use.
+type Aggregator struct {
+	trueN, falseN, noneN int
+}
+
+// Add records v.
+func (a *Aggregator) Add(v TriState) {
+	switch v.value {
+	case True:
+		a.trueN++
+	case False:
+		a.falseN++
+	default:
+		a.noneN++
+	}
+}
+
+// Merge folds the values recorded by other into a.
+func (a *Aggregator) Merge(other Aggregator) {
+	a.trueN += other.trueN
+	a.falseN += other.falseN
+	a.noneN += other.noneN
+}
+
+// Reset discards all recorded values.
+func (a *Aggregator) Reset() { *a = Aggregator{} }
+
+// Conjunction returns the Kleene AND of the values added so far, as All
+// would. It is True before any value is added.
+func (a *Aggregator) Conjunction() TriState {
+	return conjunction(a.falseN, a.noneN)
+}
+
+// Disjunction returns the Kleene OR of the values added so far, as Any
+// would. It is False before any value is added.
+func (a *Aggregator) Disjunction() TriState {
+	return disjunction(a.trueN, a.noneN)
+}
+
+// Counts returns how many True, False, and None values have been added.
+func (a *Aggregator) Counts() (trueN, falseN, noneN int) {
+	return a.trueN, a.falseN, a.noneN
+}
@@ -0,0 +1,95 @@
+package tristate
+
+import "testing"
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name                string
+		input               []TriState
+		wantT, wantF, wantN int
+	}{
+		{"Empty", nil, 0, 0, 0},
+		{"Mixed", []TriState{New(true), New(false), {}, New(true), {}}, 2, 1, 2},
+		{"All None", []TriState{{}, {}}, 0, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, gotF, gotN := Count(tt.input)
+			if gotT != tt.wantT || gotF != tt.wantF || gotN != tt.wantN {
+				t.Errorf("Count() = (%d, %d, %d), want (%d, %d, %d)", gotT, gotF, gotN, tt.wantT, tt.wantF, tt.wantN)
+			}
+		})
+	}
+}
+
+func TestAllAny(t *testing.T) {
+	T, F, N := New(true), New(false), TriState{}
+	tests := []struct {
+		name    string
+		input   []TriState
+		wantAll State
+		wantAny State
+	}{
+		{"Empty", nil, True, False},
+		{"All true", []TriState{T, T}, True, True},
+		{"All false", []TriState{F, F}, False, False},
+		{"All none", []TriState{N, N}, None, None},
+		{"True and none", []TriState{T, N}, None, True},
+		{"False and none", []TriState{N, F}, False, None},
+		{"Mixed", []TriState{T, N, F}, False, True},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := All(tt.input).value; got != tt.wantAll {
+				t.Errorf("All() = %v, want %v", got, tt.wantAll)
+			}
+			if got := Any(tt.input).value; got != tt.wantAny {
+				t.Errorf("Any() = %v, want %v", got, tt.wantAny)
+			}
+			s := SliceOf(tt.input...)
+			if got := SliceAll(&s).value; got != tt.wantAll {
+				t.Errorf("SliceAll() = %v, want %v", got, tt.wantAll)
+			}
+			if got := SliceAny(&s).value; got != tt.wantAny {
+				t.Errorf("SliceAny() = %v, want %v", got, tt.wantAny)
+			}
+		})
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	var a Aggregator
+	if !a.Conjunction().IsTrue() || !a.Disjunction().IsFalse() {
+		t.Error("empty Aggregator did not report identity values")
+	}
+
+	steps := []struct {
+		add             TriState
+		wantAnd, wantOr State
+	}{
+		{New(true), True, True},
+		{TriState{}, None, True},
+		{New(false), False, True},
+	}
+	for _, st := range steps {
+		a.Add(st.add)
+		if got := a.Conjunction().value; got != st.wantAnd {
+			t.Errorf("after Add(%v): Conjunction() = %v, want %v", st.add.value, got, st.wantAnd)
+		}
+		if got := a.Disjunction().value; got != st.wantOr {
+			t.Errorf("after Add(%v): Disjunction() = %v, want %v", st.add.value, got, st.wantOr)
+		}
+	}
+
+	var b Aggregator
+	b.Add(New(true))
+	a.Merge(b)
+	if gotT, gotF, gotN := a.Counts(); gotT != 2 || gotF != 1 || gotN != 1 {
+		t.Errorf("Counts() = (%d, %d, %d), want (2, 1, 1)", gotT, gotF, gotN)
+	}
+
+	a.Reset()
+	if gotT, gotF, gotN := a.Counts(); gotT+gotF+gotN != 0 {
+		t.Error("Reset did not clear counts")
+	}
+}
@@ -0,0 +1,36 @@
+package tristate
+
+import "sync/atomic"
+
+// Atomic is a TriState that may be loaded and stored concurrently without
+// a lock, suited to hot-path flags read by request goroutines while admin
+// endpoints flip them. The zero value holds None. An Atomic must not be
+// copied after first use.
+type Atomic struct {
+	v     atomic.Uint32
+	guard atomic.Pointer[Guard] // consulted by TryStore
+}
+
+// NewAtomic returns an Atomic holding v.
+func NewAtomic(v TriState) *Atomic {
+	a := &Atomic{}
+	a.Store(v)
+	return a
+}
+
+// Load returns the current value.
+func (a *Atomic) Load() TriState { return TriState{value: State(a.v.Load())} }
+
+// Store sets the value to v.
+func (a *Atomic) Store(v TriState) { a.v.Store(uint32(v.value)) }
+
+// Swap sets the value to v and returns the previous value.
+func (a *Atomic) Swap(v TriState) (old TriState) {
+	return TriState{value: State(a.v.Swap(uint32(v.value)))}
+}
+
+// CompareAndSwap sets the value to new only if it is currently old, and
+// reports whether it did.
+func (a *Atomic) CompareAndSwap(old, new TriState) (swapped bool) {
+	return a.v.CompareAndSwap(uint32(old.value), uint32(new.value))
+}
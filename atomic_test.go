@@ -0,0 +1,55 @@
+package tristate
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomic(t *testing.T) {
+	var a Atomic
+	if got := a.Load(); got.value != None {
+		t.Errorf("zero Load() = %v, want None", got.value)
+	}
+
+	a.Store(New(true))
+	if got := a.Load(); got.value != True {
+		t.Errorf("Load() = %v, want True", got.value)
+	}
+	if old := a.Swap(New(false)); old.value != True {
+		t.Errorf("Swap() = %v, want True", old.value)
+	}
+	if a.CompareAndSwap(New(true), TriState{}) {
+		t.Error("CompareAndSwap succeeded with a stale old value")
+	}
+	if !a.CompareAndSwap(New(false), TriState{}) || a.Load().value != None {
+		t.Errorf("CompareAndSwap failed; value %v", a.Load().value)
+	}
+
+	if got := NewAtomic(New(false)).Load(); got.value != False {
+		t.Errorf("NewAtomic(false).Load() = %v", got.value)
+	}
+}
+
+func TestAtomic_Concurrent(t *testing.T) {
+	a := NewAtomic(TriState{})
+	var flips sync.WaitGroup
+	for range 8 {
+		flips.Go(func() {
+			for range 1000 {
+				for {
+					old := a.Load()
+					next := New(!old.IsTrue())
+					if a.CompareAndSwap(old, next) {
+						break
+					}
+				}
+			}
+		})
+	}
+	flips.Wait()
+	// 8000 flips starting from None: the first lands on True, and every
+	// later one alternates, ending on False.
+	if got := a.Load(); got.value != False {
+		t.Errorf("Load() = %v, want False", got.value)
+	}
+}
@@ -0,0 +1,87 @@
+package tristate
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Bus merges the changes of many named Observables into one stream, so a
+// single goroutine can persist or log every flag change in a process.
+// Changes published on the Bus carry the flag name as their Key. A Bus is
+// safe for concurrent use; the zero value is ready to use.
+type Bus struct {
+	mu        sync.Mutex // held while publishing, so subscribers see one change at a time
+	nextID    int
+	callbacks []observer // in registration order
+	watchers  map[chan Change]struct{}
+}
+
+// Attach publishes every subsequent change of o on the Bus under name.
+// The returned function detaches it. The same Observable may be attached
+// under several names, and to several Buses.
+func (b *Bus) Attach(name string, o *Observable) (detach func()) {
+	return o.OnChange(func(c Change) {
+		c.Key = name
+		b.publish(c)
+	})
+}
+
+// Publish sends c to every subscriber, for changes that do not come from
+// an Observable. Subscribers are called in registration order and then
+// offered c on their channel, as Observable.Store does.
+func (b *Bus) Publish(c Change) { b.publish(c) }
+
+func (b *Bus) publish(c Change) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, cb := range b.callbacks {
+		cb.fn(c)
+	}
+	for ch := range b.watchers {
+		select {
+		case ch <- c:
+		default: // the subscriber is behind; drop rather than block the publisher
+		}
+	}
+}
+
+// OnChange registers fn to be called synchronously for every change
+// published on the Bus, never concurrently with itself or other callbacks.
+// fn must not call methods of the Bus. The returned function unregisters it.
+func (b *Bus) OnChange(fn func(Change)) (cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.callbacks = append(b.callbacks, observer{id: id, fn: fn})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.callbacks = slices.DeleteFunc(b.callbacks, func(cb observer) bool { return cb.id == id })
+	}
+}
+
+// Subscribe returns a channel receiving every subsequent change until ctx
+// is done, when the channel is closed. Like Observable.Watch, changes are
+// dropped while the receiver is more than a buffer behind; use OnChange
+// where none may be lost.
+func (b *Bus) Subscribe(ctx context.Context) <-chan Change {
+	ch := make(chan Change, watchBuffer)
+	b.mu.Lock()
+	if b.watchers == nil {
+		b.watchers = map[chan Change]struct{}{}
+	}
+	b.watchers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.watchers, ch)
+		close(ch)
+	}()
+	return ch
+}
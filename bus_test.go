@@ -0,0 +1,58 @@
+package tristate
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBus(t *testing.T) {
+	var bus Bus
+	dark, beta := NewObservable(TriState{}), NewObservable(New(true))
+	detachDark := bus.Attach("dark_mode", dark)
+	bus.Attach("beta", beta)
+
+	var got []Change
+	cancel := bus.OnChange(func(c Change) { got = append(got, c) })
+	ctx, stop := context.WithCancel(context.Background())
+	ch := bus.Subscribe(ctx)
+
+	dark.Store(New(true))
+	beta.Store(New(false))
+	detachDark()
+	dark.Store(New(false)) // detached: not published
+	bus.Publish(Change{Key: "manual", Kind: Removed, Before: New(true)})
+	cancel()
+	beta.Store(TriState{})
+
+	want := []Change{
+		{Key: "dark_mode", Kind: Added, After: New(true)},
+		{Key: "beta", Kind: Changed, Before: New(true), After: New(false)},
+		{Key: "manual", Kind: Removed, Before: New(true)},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("OnChange received %+v, want %+v", got, want)
+	}
+
+	want = append(want, Change{Key: "beta", Kind: Removed, Before: New(false)})
+	for _, w := range want {
+		select {
+		case c := <-ch:
+			if c != w {
+				t.Errorf("Subscribe received %+v, want %+v", c, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a change")
+		}
+	}
+	stop()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("received a change after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
@@ -0,0 +1,53 @@
+package tristate
+
+import "time"
+
+// Clock tells the time to the time-dependent types of this package, such
+// as Expiring and Versioned, and schedules the timers of
+// Observable.OnChangeDebounced. Tests substitute a fake, like the one in
+// package tristatetest, to exercise expiry, history, and debouncing
+// without sleeping.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f on its own goroutine once d has elapsed, as
+	// time.AfterFunc does.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending call scheduled by Clock.AfterFunc. *time.Timer
+// implements it.
+type Timer interface {
+	// Stop prevents the call, reporting whether it was still pending.
+	Stop() bool
+	// Reset reschedules the call for d from now, reporting whether it was
+	// still pending.
+	Reset(d time.Duration) bool
+}
+
+// ClockFunc adapts a function into a Clock whose timers are real ones.
+type ClockFunc func() time.Time
+
+// Now calls f.
+func (f ClockFunc) Now() time.Time { return f() }
+
+// AfterFunc calls time.AfterFunc.
+func (f ClockFunc) AfterFunc(d time.Duration, fn func()) Timer { return time.AfterFunc(d, fn) }
+
+// SystemClock is the Clock reading time.Now. A nil Clock behaves the same.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// now reads c, defaulting to the system clock.
+func now(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// afterFunc schedules f on c, defaulting to the system clock.
+func afterFunc(c Clock, d time.Duration, f func()) Timer {
+	if c == nil {
+		return time.AfterFunc(d, f)
+	}
+	return c.AfterFunc(d, f)
+}
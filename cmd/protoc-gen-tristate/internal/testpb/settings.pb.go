@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: settings.proto
+
+// Test input for protoc-gen-tristate. The generated files next to it are
+// golden outputs checked by the plugin's tests; regenerate them with
+//
+//	go test ./cmd/protoc-gen-tristate -update
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Settings struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Audit    *bool                  `protobuf:"varint,1,opt,name=audit,proto3,oneof" json:"audit,omitempty"`
+	BetaMode *wrapperspb.BoolValue  `protobuf:"bytes,2,opt,name=beta_mode,json=betaMode,proto3" json:"beta_mode,omitempty"`
+	Plain    bool                   `protobuf:"varint,3,opt,name=plain,proto3" json:"plain,omitempty"`
+	Name     string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Nested   *Settings_Nested       `protobuf:"bytes,5,opt,name=nested,proto3" json:"nested,omitempty"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*Settings_ChoiceFlag
+	//	*Settings_ChoiceName
+	Choice        isSettings_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Settings) Reset() {
+	*x = Settings{}
+	mi := &file_settings_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Settings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Settings) ProtoMessage() {}
+
+func (x *Settings) ProtoReflect() protoreflect.Message {
+	mi := &file_settings_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Settings.ProtoReflect.Descriptor instead.
+func (*Settings) Descriptor() ([]byte, []int) {
+	return file_settings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Settings) GetAudit() bool {
+	if x != nil && x.Audit != nil {
+		return *x.Audit
+	}
+	return false
+}
+
+func (x *Settings) GetBetaMode() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.BetaMode
+	}
+	return nil
+}
+
+func (x *Settings) GetPlain() bool {
+	if x != nil {
+		return x.Plain
+	}
+	return false
+}
+
+func (x *Settings) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Settings) GetNested() *Settings_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Settings) GetChoice() isSettings_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *Settings) GetChoiceFlag() bool {
+	if x != nil {
+		if x, ok := x.Choice.(*Settings_ChoiceFlag); ok {
+			return x.ChoiceFlag
+		}
+	}
+	return false
+}
+
+func (x *Settings) GetChoiceName() string {
+	if x != nil {
+		if x, ok := x.Choice.(*Settings_ChoiceName); ok {
+			return x.ChoiceName
+		}
+	}
+	return ""
+}
+
+type isSettings_Choice interface {
+	isSettings_Choice()
+}
+
+type Settings_ChoiceFlag struct {
+	ChoiceFlag bool `protobuf:"varint,6,opt,name=choice_flag,json=choiceFlag,proto3,oneof"`
+}
+
+type Settings_ChoiceName struct {
+	ChoiceName string `protobuf:"bytes,7,opt,name=choice_name,json=choiceName,proto3,oneof"`
+}
+
+func (*Settings_ChoiceFlag) isSettings_Choice() {}
+
+func (*Settings_ChoiceName) isSettings_Choice() {}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plain         bool                   `protobuf:"varint,1,opt,name=plain,proto3" json:"plain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_settings_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_settings_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_settings_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Empty) GetPlain() bool {
+	if x != nil {
+		return x.Plain
+	}
+	return false
+}
+
+type Settings_Nested struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tls           *bool                  `protobuf:"varint,1,opt,name=tls,proto3,oneof" json:"tls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Settings_Nested) Reset() {
+	*x = Settings_Nested{}
+	mi := &file_settings_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Settings_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Settings_Nested) ProtoMessage() {}
+
+func (x *Settings_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_settings_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Settings_Nested.ProtoReflect.Descriptor instead.
+func (*Settings_Nested) Descriptor() ([]byte, []int) {
+	return file_settings_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Settings_Nested) GetTls() bool {
+	if x != nil && x.Tls != nil {
+		return *x.Tls
+	}
+	return false
+}
+
+var File_settings_proto protoreflect.FileDescriptor
+
+const file_settings_proto_rawDesc = "" +
+	"\n" +
+	"\x0esettings.proto\x12\rtristate.test\x1a\x1egoogle/protobuf/wrappers.proto\"\xc3\x02\n" +
+	"\bSettings\x12\x19\n" +
+	"\x05audit\x18\x01 \x01(\bH\x01R\x05audit\x88\x01\x01\x127\n" +
+	"\tbeta_mode\x18\x02 \x01(\v2\x1a.google.protobuf.BoolValueR\bbetaMode\x12\x14\n" +
+	"\x05plain\x18\x03 \x01(\bR\x05plain\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x126\n" +
+	"\x06nested\x18\x05 \x01(\v2\x1e.tristate.test.Settings.NestedR\x06nested\x12!\n" +
+	"\vchoice_flag\x18\x06 \x01(\bH\x00R\n" +
+	"choiceFlag\x12!\n" +
+	"\vchoice_name\x18\a \x01(\tH\x00R\n" +
+	"choiceName\x1a'\n" +
+	"\x06Nested\x12\x15\n" +
+	"\x03tls\x18\x01 \x01(\bH\x00R\x03tls\x88\x01\x01B\x06\n" +
+	"\x04_tlsB\b\n" +
+	"\x06choiceB\b\n" +
+	"\x06_audit\"\x1d\n" +
+	"\x05Empty\x12\x14\n" +
+	"\x05plain\x18\x01 \x01(\bR\x05plainB2Z0tristate/cmd/protoc-gen-tristate/internal/testpbb\x06proto3"
+
+var (
+	file_settings_proto_rawDescOnce sync.Once
+	file_settings_proto_rawDescData []byte
+)
+
+func file_settings_proto_rawDescGZIP() []byte {
+	file_settings_proto_rawDescOnce.Do(func() {
+		file_settings_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_settings_proto_rawDesc), len(file_settings_proto_rawDesc)))
+	})
+	return file_settings_proto_rawDescData
+}
+
+var file_settings_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_settings_proto_goTypes = []any{
+	(*Settings)(nil),             // 0: tristate.test.Settings
+	(*Empty)(nil),                // 1: tristate.test.Empty
+	(*Settings_Nested)(nil),      // 2: tristate.test.Settings.Nested
+	(*wrapperspb.BoolValue)(nil), // 3: google.protobuf.BoolValue
+}
+var file_settings_proto_depIdxs = []int32{
+	3, // 0: tristate.test.Settings.beta_mode:type_name -> google.protobuf.BoolValue
+	2, // 1: tristate.test.Settings.nested:type_name -> tristate.test.Settings.Nested
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_settings_proto_init() }
+func file_settings_proto_init() {
+	if File_settings_proto != nil {
+		return
+	}
+	file_settings_proto_msgTypes[0].OneofWrappers = []any{
+		(*Settings_ChoiceFlag)(nil),
+		(*Settings_ChoiceName)(nil),
+	}
+	file_settings_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_settings_proto_rawDesc), len(file_settings_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_settings_proto_goTypes,
+		DependencyIndexes: file_settings_proto_depIdxs,
+		MessageInfos:      file_settings_proto_msgTypes,
+	}.Build()
+	File_settings_proto = out.File
+	file_settings_proto_goTypes = nil
+	file_settings_proto_depIdxs = nil
+}
@@ -0,0 +1,34 @@
+package testpb
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"tristate"
+)
+
+func TestSettings_TriState(t *testing.T) {
+	var nilSettings *Settings
+	if got := nilSettings.TriState(); got != (SettingsTriState{}) {
+		t.Errorf("nil TriState() = %+v", got)
+	}
+
+	m := &Settings{Audit: proto.Bool(false), Plain: true}
+	got := m.TriState()
+	if !got.Audit.IsFalse() || !got.BetaMode.IsNone() {
+		t.Errorf("TriState() = %+v", got)
+	}
+
+	m.SetTriState(SettingsTriState{BetaMode: tristate.New(true)})
+	if m.Audit != nil || !proto.Equal(m.BetaMode, wrapperspb.Bool(true)) || !m.Plain {
+		t.Errorf("after SetTriState: %v", m)
+	}
+
+	n := &Settings_Nested{}
+	n.SetTriState(Settings_NestedTriState{Tls: tristate.New(true)})
+	if n.Tls == nil || !*n.Tls || !n.TriState().Tls.IsTrue() {
+		t.Errorf("nested = %v", n)
+	}
+}
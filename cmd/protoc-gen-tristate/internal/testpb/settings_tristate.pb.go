@@ -0,0 +1,52 @@
+// Code generated by protoc-gen-tristate. DO NOT EDIT.
+// source: settings.proto
+
+package testpb
+
+import (
+	tristate "tristate"
+	tristatepb "tristate/tristatepb"
+)
+
+// SettingsTriState holds the tri-state fields of Settings.
+type SettingsTriState struct {
+	Audit    tristate.TriState
+	BetaMode tristate.TriState
+}
+
+// TriState returns the tri-state fields of x, with None for unset fields.
+func (x *Settings) TriState() SettingsTriState {
+	if x == nil {
+		return SettingsTriState{}
+	}
+	return SettingsTriState{
+		Audit:    tristate.FromPtr(x.Audit),
+		BetaMode: tristatepb.FromBoolValue(x.BetaMode),
+	}
+}
+
+// SetTriState stores v in the tri-state fields of x, clearing those that are None.
+func (x *Settings) SetTriState(v SettingsTriState) {
+	x.Audit = v.Audit.Ptr()
+	x.BetaMode = tristatepb.ToBoolValue(v.BetaMode)
+}
+
+// Settings_NestedTriState holds the tri-state fields of Settings_Nested.
+type Settings_NestedTriState struct {
+	Tls tristate.TriState
+}
+
+// TriState returns the tri-state fields of x, with None for unset fields.
+func (x *Settings_Nested) TriState() Settings_NestedTriState {
+	if x == nil {
+		return Settings_NestedTriState{}
+	}
+	return Settings_NestedTriState{
+		Tls: tristate.FromPtr(x.Tls),
+	}
+}
+
+// SetTriState stores v in the tri-state fields of x, clearing those that are None.
+func (x *Settings_Nested) SetTriState(v Settings_NestedTriState) {
+	x.Tls = v.Tls.Ptr()
+}
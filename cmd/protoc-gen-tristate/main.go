@@ -0,0 +1,146 @@
+// Command protoc-gen-tristate is a protoc plugin generating conversions
+// between protobuf messages and tristate.TriState. Run it alongside
+// protoc-gen-go:
+//
+//	protoc --go_out=. --tristate_out=. settings.proto
+//
+// For every message with an `optional bool` or google.protobuf.BoolValue
+// field, it writes to <file>_tristate.pb.go a struct named after the
+// message with the suffix TriState, holding those fields as TriState
+// values, and two methods on the message converting to and from it:
+//
+//	func (x *Settings) TriState() SettingsTriState
+//	func (x *Settings) SetTriState(v SettingsTriState)
+//
+// Unset fields convert to None and None clears a field, so presence is
+// preserved in both directions. Only the open and hybrid Go APIs are
+// supported; files using the opaque API are rejected.
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/gofeaturespb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	tristatePackage   = protogen.GoImportPath("tristate")
+	tristatepbPackage = protogen.GoImportPath("tristate/tristatepb")
+)
+
+func main() {
+	protogen.Options{}.Run(generate)
+}
+
+// generate writes a _tristate.pb.go file for every requested file that
+// declares at least one tri-state field.
+func generate(gen *protogen.Plugin) error {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		msgs := triStateMessages(f.Messages)
+		if len(msgs) == 0 {
+			continue
+		}
+		if f.APILevel == gofeaturespb.GoFeatures_API_OPAQUE {
+			return fmt.Errorf("%s: the opaque Go API is not supported", f.Desc.Path())
+		}
+		generateFile(gen, f, msgs)
+	}
+	return nil
+}
+
+func generateFile(gen *protogen.Plugin, f *protogen.File, msgs []*protogen.Message) {
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_tristate.pb.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-tristate. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+	for _, m := range msgs {
+		generateMessage(g, m)
+	}
+}
+
+func generateMessage(g *protogen.GeneratedFile, m *protogen.Message) {
+	triState := g.QualifiedGoIdent(tristatePackage.Ident("TriState"))
+	name := m.GoIdent.GoName + "TriState"
+	fields := triStateFields(m)
+
+	g.P()
+	g.P("// ", name, " holds the tri-state fields of ", m.GoIdent.GoName, ".")
+	g.P("type ", name, " struct {")
+	for _, fd := range fields {
+		g.P(fd.GoName, " ", triState)
+	}
+	g.P("}")
+
+	g.P()
+	g.P("// TriState returns the tri-state fields of x, with None for unset fields.")
+	g.P("func (x *", m.GoIdent, ") TriState() ", name, " {")
+	g.P("if x == nil {")
+	g.P("return ", name, "{}")
+	g.P("}")
+	g.P("return ", name, "{")
+	for _, fd := range fields {
+		if isWrapper(fd) {
+			g.P(fd.GoName, ": ", tristatepbPackage.Ident("FromBoolValue"), "(x.", fd.GoName, "),")
+		} else {
+			g.P(fd.GoName, ": ", tristatePackage.Ident("FromPtr"), "(x.", fd.GoName, "),")
+		}
+	}
+	g.P("}")
+	g.P("}")
+
+	g.P()
+	g.P("// SetTriState stores v in the tri-state fields of x, clearing those that are None.")
+	g.P("func (x *", m.GoIdent, ") SetTriState(v ", name, ") {")
+	for _, fd := range fields {
+		if isWrapper(fd) {
+			g.P("x.", fd.GoName, " = ", tristatepbPackage.Ident("ToBoolValue"), "(v.", fd.GoName, ")")
+		} else {
+			g.P("x.", fd.GoName, " = v.", fd.GoName, ".Ptr()")
+		}
+	}
+	g.P("}")
+}
+
+// triStateMessages returns msgs and their nested messages, in declaration
+// order, that have at least one tri-state field.
+func triStateMessages(msgs []*protogen.Message) []*protogen.Message {
+	var out []*protogen.Message
+	for _, m := range msgs {
+		if m.Desc.IsMapEntry() {
+			continue
+		}
+		if len(triStateFields(m)) > 0 {
+			out = append(out, m)
+		}
+		out = append(out, triStateMessages(m.Messages)...)
+	}
+	return out
+}
+
+// triStateFields returns the fields of m generated as *bool or
+// *wrapperspb.BoolValue. Members of a real oneof are skipped: their Go
+// representation is a wrapper type, not a pointer field.
+func triStateFields(m *protogen.Message) []*protogen.Field {
+	var out []*protogen.Field
+	for _, fd := range m.Fields {
+		if fd.Oneof != nil && !fd.Oneof.Desc.IsSynthetic() || fd.Desc.IsList() || fd.Desc.IsMap() {
+			continue
+		}
+		if fd.Desc.Kind() == protoreflect.BoolKind && fd.Desc.HasPresence() || isWrapper(fd) {
+			out = append(out, fd)
+		}
+	}
+	return out
+}
+
+func isWrapper(fd *protogen.Field) bool {
+	return fd.Message != nil && fd.Message.Desc.FullName() == "google.protobuf.BoolValue"
+}
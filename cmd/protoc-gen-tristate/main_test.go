@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in internal/testpb")
+
+const testdir = "internal/testpb"
+
+// run compiles testdir/settings.proto and runs protoc-gen-go and the
+// generator on it, returning the generated files by name.
+func run(t *testing.T) map[string]string {
+	t.Helper()
+	c := protocompile.Compiler{
+		Resolver:       protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{testdir}}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	files, err := c.Compile(context.Background(), "settings.proto")
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"settings.proto"},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+			protodesc.ToFileDescriptorProto(files[0]),
+		},
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("protogen.New failed: %v", err)
+	}
+	for _, f := range gen.Files {
+		if f.Generate {
+			internal_gengo.GenerateFile(gen, f)
+		}
+	}
+	if err := generate(gen); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatalf("plugin error: %s", resp.GetError())
+	}
+	out := map[string]string{}
+	for _, f := range resp.File {
+		out[f.GetName()] = f.GetContent()
+	}
+	return out
+}
+
+func TestGolden(t *testing.T) {
+	for name, content := range run(t) {
+		path := filepath.Join(testdir, name)
+		if *update {
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading golden file: %v", err)
+		}
+		if content != string(want) {
+			t.Errorf("%s differs from the generated output; run go test -update", path)
+		}
+	}
+}
+
+func TestGenerate_Output(t *testing.T) {
+	// Compare with whitespace collapsed, since the output is gofmt-aligned.
+	got := strings.Join(strings.Fields(run(t)["settings_tristate.pb.go"]), " ")
+	for _, want := range []string{
+		"type SettingsTriState struct",
+		"Audit: tristate.FromPtr(x.Audit)",
+		"BetaMode: tristatepb.FromBoolValue(x.BetaMode)",
+		"type Settings_NestedTriState struct",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Plain", "ChoiceFlag", "EmptyTriState"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("output unexpectedly contains %q", unwanted)
+		}
+	}
+}
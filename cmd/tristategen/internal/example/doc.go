@@ -0,0 +1,5 @@
+// Package example holds the output of tristategen for features.yaml, which
+// serves as the generator's golden file.
+package example
+
+//go:generate go run tristate/cmd/tristategen -schema features.yaml -o features_gen.go
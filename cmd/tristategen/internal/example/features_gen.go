@@ -0,0 +1,63 @@
+// Code generated by tristategen. DO NOT EDIT.
+
+package example
+
+import (
+	"errors"
+	"fmt"
+
+	"tristate"
+)
+
+// Features holds the feature switches of the example service.
+type Features struct {
+	// DarkMode enables the dark theme.
+	DarkMode   tristate.TriState `json:"dark_mode,omitzero" tristate:"default=false"`
+	Audit      tristate.TriState `json:"audit,omitzero" tristate:"required,default=true"`
+	BetaSearch tristate.TriState `json:"beta-search,omitzero" tristate:"required"`
+	TLS        tristate.TriState `json:"tls,omitzero"`
+}
+
+// Merge returns c with every explicitly set field of src layered on top.
+// None fields of src never override c.
+func (c Features) Merge(src Features) Features {
+	if !src.DarkMode.IsNone() {
+		c.DarkMode = src.DarkMode
+	}
+	if !src.Audit.IsNone() {
+		c.Audit = src.Audit
+	}
+	if !src.BetaSearch.IsNone() {
+		c.BetaSearch = src.BetaSearch
+	}
+	if !src.TLS.IsNone() {
+		c.TLS = src.TLS
+	}
+	return c
+}
+
+// WithDefaults returns c with every None field that has a schema default
+// set to it.
+func (c Features) WithDefaults() Features {
+	if c.DarkMode.IsNone() {
+		c.DarkMode = tristate.New(false)
+	}
+	if c.Audit.IsNone() {
+		c.Audit = tristate.New(true)
+	}
+	return c
+}
+
+// Validate reports every required field that is None. The result joins
+// one error per missing field, each wrapping tristate.ErrRequired, or is
+// nil when every required field is set.
+func (c Features) Validate() error {
+	var errs []error
+	if c.Audit.IsNone() {
+		errs = append(errs, fmt.Errorf("%w: %s", tristate.ErrRequired, "audit"))
+	}
+	if c.BetaSearch.IsNone() {
+		errs = append(errs, fmt.Errorf("%w: %s", tristate.ErrRequired, "beta-search"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,42 @@
+package example
+
+import (
+	"errors"
+	"testing"
+
+	"tristate"
+)
+
+func TestFeatures(t *testing.T) {
+	base := Features{DarkMode: tristate.New(true), TLS: tristate.New(true)}
+	got := base.Merge(Features{DarkMode: tristate.New(false), Audit: tristate.New(false)})
+	if want := (Features{DarkMode: tristate.New(false), Audit: tristate.New(false), TLS: tristate.New(true)}); got != want {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+
+	if got, want := (Features{}).WithDefaults(), (Features{DarkMode: tristate.New(false), Audit: tristate.New(true)}); got != want {
+		t.Errorf("WithDefaults() = %+v, want %+v", got, want)
+	}
+
+	err := Features{}.WithDefaults().Validate()
+	if !errors.Is(err, tristate.ErrRequired) || err.Error() != "tristate: value required: beta-search" {
+		t.Errorf("Validate() = %v", err)
+	}
+	if err := (Features{Audit: tristate.New(true), BetaSearch: tristate.New(false)}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+// The generated tags agree with the generated methods.
+func TestFeatures_Tags(t *testing.T) {
+	var f Features
+	if err := tristate.ApplyDefaults(&f); err != nil {
+		t.Fatal(err)
+	}
+	if f != (Features{}).WithDefaults() {
+		t.Errorf("ApplyDefaults() = %+v, want %+v", f, Features{}.WithDefaults())
+	}
+	if (tristate.ValidateStruct(f) == nil) != (f.Validate() == nil) {
+		t.Errorf("ValidateStruct and Validate disagree on %+v", f)
+	}
+}
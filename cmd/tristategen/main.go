@@ -0,0 +1,266 @@
+// Command tristategen generates a typed configuration struct of TriState
+// fields from a small schema, along with methods to layer, default, and
+// validate it, so config layers need not be written and kept in sync by
+// hand. The schema is YAML or JSON:
+//
+//	package: config
+//	type: Features
+//	doc: Features holds the feature switches of the service.
+//	fields:
+//	  - name: dark_mode
+//	    default: false
+//	    doc: Enables the dark theme.
+//	  - name: audit
+//	    required: true
+//
+// Run it with go generate:
+//
+//	//go:generate go run tristate/cmd/tristategen -schema features.yaml -o features_gen.go
+//
+// The generated struct has one TriState field per schema field, named in
+// Go case (dark_mode becomes DarkMode unless go_name is given) and tagged
+// with the schema name for JSON and with its default and required options
+// for tristate.ApplyDefaults and tristate.ValidateStruct. Its methods are:
+//
+//	func (c Features) Merge(src Features) Features // explicit fields of src win
+//	func (c Features) WithDefaults() Features      // None fields take their defaults
+//	func (c Features) Validate() error             // required fields must be set
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"go.yaml.in/yaml/v3"
+
+	"tristate"
+)
+
+// Schema describes the struct to generate.
+type Schema struct {
+	Package string  `yaml:"package"`
+	Type    string  `yaml:"type"`
+	Doc     string  `yaml:"doc"`
+	Fields  []Field `yaml:"fields"`
+}
+
+// Field describes one TriState field. Default may be any spelling accepted
+// by tristate.Parse, or a YAML or JSON bool.
+type Field struct {
+	Name     string `yaml:"name"`
+	GoName   string `yaml:"go_name"`
+	Doc      string `yaml:"doc"`
+	Default  any    `yaml:"default"`
+	Required bool   `yaml:"required"`
+
+	defaultValue tristate.TriState
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "path of the YAML or JSON schema")
+	out := flag.String("o", "", "output file (default standard output)")
+	flag.Parse()
+	if *schemaPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: tristategen -schema file [-o output]")
+		os.Exit(2)
+	}
+	if err := run(*schemaPath, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "tristategen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, out string) error {
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+	var s Schema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%s: %w", schemaPath, err)
+	}
+	src, err := generate(&s)
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaPath, err)
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+// generate validates s and returns the formatted Go source for it.
+func generate(s *Schema) ([]byte, error) {
+	if !token.IsIdentifier(s.Package) {
+		return nil, fmt.Errorf("invalid package name %q", s.Package)
+	}
+	if !token.IsIdentifier(s.Type) || !token.IsExported(s.Type) {
+		return nil, fmt.Errorf("type name %q is not an exported identifier", s.Type)
+	}
+	if len(s.Fields) == 0 {
+		return nil, fmt.Errorf("type %s has no fields", s.Type)
+	}
+	seen := map[string]bool{}
+	for i := range s.Fields {
+		f := &s.Fields[i]
+		if f.Name == "" {
+			return nil, fmt.Errorf("field %d has no name", i)
+		}
+		if !validName(f.Name) {
+			return nil, fmt.Errorf("field name %q is not a valid JSON tag name", f.Name)
+		}
+		if f.GoName == "" {
+			f.GoName = goName(f.Name)
+		}
+		if !token.IsIdentifier(f.GoName) || !token.IsExported(f.GoName) {
+			return nil, fmt.Errorf("field %s: Go name %q is not an exported identifier", f.Name, f.GoName)
+		}
+		if seen[f.GoName] {
+			return nil, fmt.Errorf("field %s: duplicate Go name %s", f.Name, f.GoName)
+		}
+		seen[f.GoName] = true
+		if f.Default != nil {
+			v, err := tristate.Parse(fmt.Sprint(f.Default))
+			if err != nil {
+				return nil, fmt.Errorf("field %s: bad default: %w", f.Name, err)
+			}
+			f.defaultValue = v
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, s); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// validName reports whether name can be used as a JSON tag name inside a
+// struct tag. It follows encoding/json, but also rejects '%' and spaces.
+func validName(name string) bool {
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("!#$&()*+-./:;<=>?@[]^_{|}~", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// goName converts a snake_case or kebab-case name to an exported Go name.
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// HasRequired reports whether any field is required.
+func (s *Schema) HasRequired() bool {
+	return slices.ContainsFunc(s.Fields, func(f Field) bool { return f.Required })
+}
+
+// Tag returns the struct tag of the field.
+func (f Field) Tag() string {
+	var opts []string
+	if f.Required {
+		opts = append(opts, "required")
+	}
+	if !f.defaultValue.IsNone() {
+		opts = append(opts, "default="+f.defaultValue.String())
+	}
+	tag := fmt.Sprintf(`json:"%s,omitzero"`, f.Name)
+	if len(opts) > 0 {
+		tag += fmt.Sprintf(` tristate:"%s"`, strings.Join(opts, ","))
+	}
+	return "`" + tag + "`"
+}
+
+// DefaultExpr returns the Go expression of the field's default, or "" if
+// it has none.
+func (f Field) DefaultExpr() string {
+	if b, ok := f.defaultValue.Bool(); ok {
+		return fmt.Sprintf("tristate.New(%t)", b)
+	}
+	return ""
+}
+
+// comment returns doc as Go comment lines.
+func comment(doc string) string {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return ""
+	}
+	return "// " + strings.ReplaceAll(doc, "\n", "\n// ")
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{"comment": comment}).Parse(`// Code generated by tristategen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .HasRequired}}
+	"errors"
+	"fmt"
+{{end}}
+	"tristate"
+)
+
+{{with .Doc}}{{comment .}}{{else}}// {{.Type}} is a tri-state configuration layer.{{end}}
+type {{.Type}} struct {
+{{- range .Fields}}
+	{{with .Doc}}{{comment .}}
+	{{end}}{{.GoName}} tristate.TriState {{.Tag}}
+{{- end}}
+}
+
+// Merge returns c with every explicitly set field of src layered on top.
+// None fields of src never override c.
+func (c {{.Type}}) Merge(src {{.Type}}) {{.Type}} {
+{{- range .Fields}}
+	if !src.{{.GoName}}.IsNone() {
+		c.{{.GoName}} = src.{{.GoName}}
+	}
+{{- end}}
+	return c
+}
+
+// WithDefaults returns c with every None field that has a schema default
+// set to it.
+func (c {{.Type}}) WithDefaults() {{.Type}} {
+{{- range $f := .Fields}}{{with $f.DefaultExpr}}
+	if c.{{$f.GoName}}.IsNone() {
+		c.{{$f.GoName}} = {{.}}
+	}
+{{- end}}{{end}}
+	return c
+}
+
+// Validate reports every required field that is None. The result joins
+// one error per missing field, each wrapping tristate.ErrRequired, or is
+// nil when every required field is set.
+func (c {{.Type}}) Validate() error {
+{{- if not .HasRequired}}
+	return nil
+{{- else}}
+	var errs []error
+{{- range .Fields}}{{if .Required}}
+	if c.{{.GoName}}.IsNone() {
+		errs = append(errs, fmt.Errorf("%w: %s", tristate.ErrRequired, {{printf "%q" .Name}}))
+	}
+{{- end}}{{end}}
+	return errors.Join(errs...)
+{{- end}}
+}
+`))
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden file in internal/example")
+
+const testdir = "internal/example"
+
+func TestGolden(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "features_gen.go")
+	if err := run(filepath.Join(testdir, "features.yaml"), out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join(testdir, "features_gen.go")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s is stale; run go test -update", golden)
+	}
+}
+
+func TestGenerate_NoRequired(t *testing.T) {
+	src, err := generate(&Schema{Package: "p", Type: "T", Fields: []Field{{Name: "x", Default: true}}})
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if s := string(src); strings.Contains(s, `"errors"`) || !strings.Contains(s, "return nil") {
+		t.Errorf("unexpected output for a schema without required fields:\n%s", s)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  Schema
+		wantErr string
+	}{
+		{"Bad package", Schema{Package: "my-pkg", Type: "T", Fields: []Field{{Name: "x"}}}, "invalid package name"},
+		{"Unexported type", Schema{Package: "p", Type: "t", Fields: []Field{{Name: "x"}}}, "not an exported identifier"},
+		{"No fields", Schema{Package: "p", Type: "T"}, "no fields"},
+		{"Unnamed field", Schema{Package: "p", Type: "T", Fields: []Field{{}}}, "has no name"},
+		{"Duplicate", Schema{Package: "p", Type: "T", Fields: []Field{{Name: "a_b"}, {Name: "a-b"}}}, "duplicate Go name AB"},
+		{"Percent in name", Schema{Package: "p", Type: "T", Fields: []Field{{Name: "rate_50%", GoName: "Rate"}}}, "not a valid JSON tag name"},
+		{"Quote in name", Schema{Package: "p", Type: "T", Fields: []Field{{Name: `a"b`, GoName: "AB"}}}, "not a valid JSON tag name"},
+		{"Backtick in name", Schema{Package: "p", Type: "T", Fields: []Field{{Name: "a`b", GoName: "AB"}}}, "not a valid JSON tag name"},
+		{"Comma in name", Schema{Package: "p", Type: "T", Fields: []Field{{Name: "a,b", GoName: "AB"}}}, "not a valid JSON tag name"},
+		{"Space in name", Schema{Package: "p", Type: "T", Fields: []Field{{Name: "a b", GoName: "AB"}}}, "not a valid JSON tag name"},
+		{"Bad default", Schema{Package: "p", Type: "T", Fields: []Field{{Name: "x", Default: "maybe"}}}, "bad default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(&tt.schema)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("generate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"dark_mode":   "DarkMode",
+		"beta-search": "BetaSearch",
+		"audit":       "Audit",
+		"a.b_c":       "ABC",
+	}
+	for in, want := range tests {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "flags.json")
+	if err := os.WriteFile(schema, []byte(`{"package": "flags", "type": "Flags", "fields": [{"name": "audit", "default": true}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "flags_gen.go")
+	if err := run(schema, out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	src, _ := os.ReadFile(out)
+	if !strings.Contains(string(src), `Audit tristate.TriState `+"`"+`json:"audit,omitzero" tristate:"default=true"`+"`") {
+		t.Errorf("unexpected output:\n%s", src)
+	}
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// tristatePath is the import path of the tristate package.
+const tristatePath = "tristate"
+
+// Analyzer reports and fixes the declarations and uses of the *bool
+// fields named by its -fields flag.
+var Analyzer = &analysis.Analyzer{
+	Name:     "tristatemigrate",
+	Doc:      "rewrite selected *bool struct fields to tristate.TriState",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var fields fieldSet
+
+func init() {
+	Analyzer.Flags.Var(&fields, "fields", "comma-separated fields to migrate, as import/path.Type.Field")
+}
+
+// fieldSet is the set of selected fields, keyed "import/path.Type.Field".
+type fieldSet map[string]bool
+
+func (s *fieldSet) String() string {
+	var keys []string
+	for k := range *s {
+		keys = append(keys, k)
+	}
+	return strings.Join(keys, ",")
+}
+
+func (s *fieldSet) Set(v string) error {
+	*s = fieldSet{}
+	for _, f := range strings.Split(v, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		if strings.Count(f[strings.LastIndex(f, "/")+1:], ".") < 2 {
+			return fmt.Errorf("field %q is not of the form import/path.Type.Field", f)
+		}
+		(*s)[f] = true
+	}
+	return nil
+}
+
+// fieldKey returns the key of field as a member of the named type owner.
+func fieldKey(owner *types.Named, field string) string {
+	obj := owner.Obj()
+	if obj.Pkg() == nil {
+		return ""
+	}
+	return obj.Pkg().Path() + "." + obj.Name() + "." + field
+}
+
+func isBoolPtr(t types.Type) bool {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	b, ok := p.Elem().Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Bool
+}
+
+func named(t types.Type) *types.Named {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, _ := types.Unalias(t).(*types.Named)
+	return n
+}
+
+// selected reports whether sel reads a selected *bool field.
+func selected(pass *analysis.Pass, sel *ast.SelectorExpr) bool {
+	s, ok := pass.TypesInfo.Selections[sel]
+	if !ok || s.Kind() != types.FieldVal || !isBoolPtr(s.Type()) {
+		return false
+	}
+	// Walk embedded fields to the struct declaring the field.
+	t := s.Recv()
+	for _, i := range s.Index()[:len(s.Index())-1] {
+		st, ok := named(t).Underlying().(*types.Struct)
+		if !ok {
+			return false
+		}
+		t = st.Field(i).Type()
+	}
+	n := named(t)
+	return n != nil && fields[fieldKey(n, sel.Sel.Name)]
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	m := &migrator{pass: pass, qualifiers: map[*ast.File]string{}}
+
+	for _, f := range pass.Files {
+		m.file = f
+		ast.Inspect(f, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				m.typeSpec(ts)
+			}
+			return true
+		})
+	}
+
+	nodeTypes := []ast.Node{(*ast.File)(nil), (*ast.SelectorExpr)(nil), (*ast.CompositeLit)(nil)}
+	ins.WithStack(nodeTypes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.File:
+			m.file = n
+		case *ast.SelectorExpr:
+			if selected(pass, n) {
+				m.use(n, stack)
+			}
+		case *ast.CompositeLit:
+			m.compositeLit(n)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// migrator builds the fixes for one package.
+type migrator struct {
+	pass       *analysis.Pass
+	file       *ast.File
+	qualifiers map[*ast.File]string
+}
+
+// typeSpec rewrites the selected fields declared in ts.
+func (m *migrator) typeSpec(ts *ast.TypeSpec) {
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return
+	}
+	obj, _ := m.pass.TypesInfo.Defs[ts.Name].(*types.TypeName)
+	if obj == nil {
+		return
+	}
+	n, _ := obj.Type().(*types.Named)
+	if n == nil {
+		return
+	}
+	for _, f := range st.Fields.List {
+		var sel []string
+		for _, name := range f.Names {
+			if fields[fieldKey(n, name.Name)] {
+				sel = append(sel, name.Name)
+			}
+		}
+		if len(sel) == 0 || !isBoolPtr(m.pass.TypesInfo.TypeOf(f.Type)) {
+			continue
+		}
+		if len(sel) != len(f.Names) {
+			m.pass.Reportf(f.Pos(), "field %s shares its declaration with unselected fields; split it to migrate", sel[0])
+			continue
+		}
+		m.report(f.Type, fmt.Sprintf("field %s.%s: *bool becomes TriState", ts.Name.Name, sel[0]),
+			m.replace(f.Type, m.qualifier()+".TriState"))
+	}
+}
+
+// use rewrites a use of a selected field according to its context.
+func (m *migrator) use(sel *ast.SelectorExpr, stack []ast.Node) {
+	parent := func(i int) ast.Node {
+		if len(stack) < i+2 {
+			return nil
+		}
+		return stack[len(stack)-i-2]
+	}
+	self := m.text(sel)
+
+	switch p := parent(0).(type) {
+	case *ast.BinaryExpr:
+		if (p.Op == token.EQL || p.Op == token.NEQ) && (m.isNil(p.X) || m.isNil(p.Y)) {
+			repl := self + ".IsNone()"
+			if p.Op == token.NEQ {
+				repl = "!" + repl
+			}
+			m.report(p, "nil check becomes IsNone", m.replace(p, repl))
+			return
+		}
+	case *ast.StarExpr:
+		if as, ok := parent(1).(*ast.AssignStmt); ok && as.Tok == token.ASSIGN && len(as.Lhs) == 1 && as.Lhs[0] == p {
+			m.report(as, "store through the pointer becomes an assignment",
+				m.replace(as, fmt.Sprintf("%s = %s.New(%s)", self, m.qualifier(), m.text(as.Rhs[0]))))
+			return
+		}
+		m.report(p, "dereference becomes ValueOr", m.replace(p, self+".ValueOr(false)"))
+		return
+	case *ast.AssignStmt:
+		if i := index(p.Lhs, sel); i >= 0 {
+			if p.Tok != token.ASSIGN || len(p.Lhs) != len(p.Rhs) {
+				m.pass.Reportf(sel.Pos(), "assignment to %s needs manual migration", self)
+				return
+			}
+			if !m.isSelected(p.Rhs[i]) {
+				m.report(p.Rhs[i], "assigned value becomes a TriState", m.replace(p.Rhs[i], m.fromPtr(p.Rhs[i])))
+			}
+			return
+		}
+		if i := index(p.Rhs, sel); i >= 0 && len(p.Lhs) == len(p.Rhs) && m.isSelected(p.Lhs[i]) {
+			return // copying between migrated fields needs no change
+		}
+	case *ast.KeyValueExpr:
+		if p.Value == sel && m.isSelectedKey(parent(1), p) {
+			return // as above, within a composite literal
+		}
+	}
+	m.report(sel, "use as *bool becomes Ptr", m.replace(sel, self+".Ptr()"))
+}
+
+// compositeLit rewrites the values given to selected fields in lit.
+func (m *migrator) compositeLit(lit *ast.CompositeLit) {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok && m.isSelectedKey(lit, kv) && !m.isSelected(kv.Value) {
+			m.report(kv.Value, "field value becomes a TriState", m.replace(kv.Value, m.fromPtr(kv.Value)))
+		}
+	}
+}
+
+// isSelected reports whether e reads a selected field.
+func (m *migrator) isSelected(e ast.Expr) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	return ok && selected(m.pass, sel)
+}
+
+// isSelectedKey reports whether kv, an element of the composite literal
+// lit, sets a selected field.
+func (m *migrator) isSelectedKey(lit ast.Node, kv *ast.KeyValueExpr) bool {
+	cl, ok := lit.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	n := named(m.pass.TypesInfo.TypeOf(cl))
+	key, ok := kv.Key.(*ast.Ident)
+	if n == nil || !ok || !fields[fieldKey(n, key.Name)] {
+		return false
+	}
+	v, ok := m.pass.TypesInfo.Uses[key].(*types.Var)
+	return ok && v.IsField() && isBoolPtr(v.Type())
+}
+
+// fromPtr returns the TriState expression for the *bool expression e.
+func (m *migrator) fromPtr(e ast.Expr) string {
+	if m.isNil(e) {
+		return m.qualifier() + ".TriState{}"
+	}
+	return fmt.Sprintf("%s.FromPtr(%s)", m.qualifier(), m.text(e))
+}
+
+func (m *migrator) isNil(e ast.Expr) bool {
+	return m.pass.TypesInfo.Types[e].IsNil()
+}
+
+func (m *migrator) report(n ast.Node, msg string, edits []analysis.TextEdit) {
+	m.pass.Report(analysis.Diagnostic{
+		Pos:     n.Pos(),
+		End:     n.End(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Migrate to tristate.TriState",
+			TextEdits: edits,
+		}},
+	})
+}
+
+// replace returns the edits replacing n with text, plus the import of the
+// tristate package if text refers to it and the file lacks it.
+func (m *migrator) replace(n ast.Node, text string) []analysis.TextEdit {
+	edits := []analysis.TextEdit{{Pos: n.Pos(), End: n.End(), NewText: []byte(text)}}
+	if q := m.qualifier(); strings.Contains(text, q+".") && !imports(m.file, tristatePath) {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     m.file.Name.End(),
+			End:     m.file.Name.End(),
+			NewText: []byte("\n\nimport " + strconv.Quote(tristatePath)),
+		})
+	}
+	return edits
+}
+
+// qualifier returns the name by which the current file refers to the
+// tristate package.
+func (m *migrator) qualifier() string {
+	if q, ok := m.qualifiers[m.file]; ok {
+		return q
+	}
+	q := "tristate"
+	for _, spec := range m.file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path == tristatePath && spec.Name != nil {
+			q = spec.Name.Name
+		}
+	}
+	m.qualifiers[m.file] = q
+	return q
+}
+
+func (m *migrator) text(n ast.Node) string {
+	var buf bytes.Buffer
+	format.Node(&buf, m.pass.Fset, n)
+	return buf.String()
+}
+
+func imports(f *ast.File, path string) bool {
+	for _, spec := range f.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+			return true
+		}
+	}
+	return false
+}
+
+func index(exprs []ast.Expr, e ast.Expr) int {
+	for i, x := range exprs {
+		if x == e {
+			return i
+		}
+	}
+	return -1
+}
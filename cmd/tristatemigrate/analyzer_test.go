@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := Analyzer.Flags.Set("fields", "a.Config.Debug, a.Config.A"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "a", "b")
+}
+
+func TestFieldSet_Set(t *testing.T) {
+	var s fieldSet
+	if err := s.Set("example.com/svc/config.Options"); err == nil {
+		t.Error("Set accepted a field without a type")
+	}
+	if err := s.Set("example.com/svc.v2/config.Options.Debug,a.B.C"); err != nil || !s["example.com/svc.v2/config.Options.Debug"] || !s["a.B.C"] {
+		t.Errorf("Set() = %v, fields %v", err, s)
+	}
+}
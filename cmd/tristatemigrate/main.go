@@ -0,0 +1,26 @@
+// Command tristatemigrate rewrites selected struct fields from *bool to
+// tristate.TriState and updates the code using them, to make adopting
+// TriState in a large codebase a mechanical change. Name the fields
+// with -fields as import/path.Type.Field and apply the edits with -fix:
+//
+//	tristatemigrate -fields example.com/svc/config.Options.Debug -fix ./...
+//
+// Declarations become TriState fields, and uses are rewritten as follows:
+//
+//	x.F == nil   x.F.IsNone()
+//	x.F != nil   !x.F.IsNone()
+//	*x.F         x.F.ValueOr(false)
+//	*x.F = v     x.F = tristate.New(v)
+//	x.F = nil    x.F = tristate.TriState{}
+//	x.F = p      x.F = tristate.FromPtr(p)
+//	F: p         F: tristate.FromPtr(p)   (in composite literals)
+//	other x.F    x.F.Ptr()
+//
+// Ptr returns a pointer to a copy, so code that mutated the field through
+// a stored pointer needs review; such uses are reported with the rest.
+// Without -fix the tool only reports what it would change.
+package main
+
+import "golang.org/x/tools/go/analysis/singlechecker"
+
+func main() { singlechecker.Main(Analyzer) }
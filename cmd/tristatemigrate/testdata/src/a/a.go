@@ -0,0 +1,33 @@
+package a
+
+type Config struct {
+	Debug   *bool // want "field Config.Debug: \\*bool becomes TriState"
+	Verbose *bool
+	A, B    *bool // want "shares its declaration"
+}
+
+func enabled(c *Config) bool {
+	return c.Debug != nil && *c.Debug // want "nil check becomes IsNone" "dereference becomes ValueOr"
+}
+
+func reset(c *Config, on bool) {
+	if c.Debug == nil { // want "nil check becomes IsNone"
+		c.Debug = &on // want "assigned value becomes a TriState"
+		return
+	}
+	*c.Debug = on // want "store through the pointer becomes an assignment"
+	c.Debug = nil // want "assigned value becomes a TriState"
+	c.Verbose = nil
+}
+
+func copyFrom(dst, src *Config) {
+	dst.Debug = src.Debug
+}
+
+func ptr(c Config) *bool {
+	return c.Debug // want "use as \\*bool becomes Ptr"
+}
+
+func literal(p *bool) Config {
+	return Config{Debug: p, Verbose: p} // want "field value becomes a TriState"
+}
@@ -0,0 +1,21 @@
+package b
+
+import (
+	ts "tristate"
+
+	"a"
+)
+
+var _ ts.TriState
+
+type Wrapper struct {
+	a.Config
+}
+
+func debug(w Wrapper) bool {
+	return w.Debug != nil // want "nil check becomes IsNone"
+}
+
+func set(w *Wrapper, p *bool) {
+	w.Debug = p // want "assigned value becomes a TriState"
+}
@@ -0,0 +1,4 @@
+// Package tristate is a stub of the real package for the analyzer tests.
+package tristate
+
+type TriState struct{ value uint8 }
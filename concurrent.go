@@ -0,0 +1,120 @@
+package tristate
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// concurrentShards is the number of independently locked shards in a
+// ConcurrentMap. It is a power of two so a hash can be masked to a shard.
+const concurrentShards = 64
+
+// ConcurrentMap is a Map safe for concurrent use, sharded across
+// independently locked buckets so read-heavy flag lookups from many
+// goroutines rarely contend. As with Map, a missing key reads as None.
+// The zero value is empty and ready to use. A ConcurrentMap must not be
+// copied after first use.
+type ConcurrentMap struct {
+	once   sync.Once
+	seed   maphash.Seed
+	shards [concurrentShards]concurrentShard
+}
+
+type concurrentShard struct {
+	mu sync.RWMutex
+	m  Map
+	_  [32]byte // pad to a 64-byte cache line to avoid false sharing between shards
+}
+
+// NewConcurrentMap returns a ConcurrentMap holding the entries of m.
+func NewConcurrentMap(m Map) *ConcurrentMap {
+	c := &ConcurrentMap{}
+	for k, v := range m {
+		c.Set(k, v)
+	}
+	return c
+}
+
+func (c *ConcurrentMap) shard(key string) *concurrentShard {
+	c.once.Do(func() { c.seed = maphash.MakeSeed() })
+	return &c.shards[maphash.String(c.seed, key)&(concurrentShards-1)]
+}
+
+// Get returns the value of key and whether it is present.
+func (c *ConcurrentMap) Get(key string) (TriState, bool) {
+	s := c.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[key]
+	return v, ok
+}
+
+// Set stores v under key.
+func (c *ConcurrentMap) Set(key string, v TriState) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = Map{}
+	}
+	s.m[key] = v
+}
+
+// Delete removes key.
+func (c *ConcurrentMap) Delete(key string) {
+	s := c.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+}
+
+// Range calls fn for every entry until it returns false. Each shard is
+// read-locked while its entries are visited, so fn must not call Set or
+// Delete; entries changed concurrently in other shards may or may not be
+// seen. Use Snapshot for a consistent copy.
+func (c *ConcurrentMap) Range(fn func(key string, v TriState) bool) {
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.RLock()
+		for k, v := range s.m {
+			if !fn(k, v) {
+				s.mu.RUnlock()
+				return
+			}
+		}
+		s.mu.RUnlock()
+	}
+}
+
+// Len returns the number of entries.
+func (c *ConcurrentMap) Len() int {
+	n := 0
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Snapshot returns a copy of every entry as a Map. All shards are locked
+// together, so the copy reflects a single point in time.
+func (c *ConcurrentMap) Snapshot() Map {
+	for i := range c.shards {
+		c.shards[i].mu.RLock()
+	}
+	n := 0
+	for i := range c.shards {
+		n += len(c.shards[i].m)
+	}
+	out := make(Map, n)
+	for i := range c.shards {
+		s := &c.shards[i]
+		for k, v := range s.m {
+			out[k] = v
+		}
+		s.mu.RUnlock()
+	}
+	return out
+}
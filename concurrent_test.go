@@ -0,0 +1,96 @@
+package tristate
+
+import (
+	"maps"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentMap(t *testing.T) {
+	c := NewConcurrentMap(Map{"a": New(true), "b": New(false)})
+	c.Set("c", TriState{})
+	c.Delete("b")
+	c.Delete("missing")
+
+	tests := []struct {
+		key    string
+		want   TriState
+		wantOK bool
+	}{
+		{"a", New(true), true},
+		{"b", TriState{}, false},
+		{"c", TriState{}, true},
+	}
+	for _, tt := range tests {
+		if got, ok := c.Get(tt.key); got != tt.want || ok != tt.wantOK {
+			t.Errorf("Get(%q) = %v, %v, want %v, %v", tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if want := (Map{"a": New(true), "c": TriState{}}); !maps.Equal(c.Snapshot(), want) {
+		t.Errorf("Snapshot() = %v, want %v", c.Snapshot(), want)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+
+	visited := 0
+	c.Range(func(string, TriState) bool { visited++; return false })
+	if visited != 1 {
+		t.Errorf("Range visited %d entries after returning false, want 1", visited)
+	}
+}
+
+func TestConcurrentMap_Concurrent(t *testing.T) {
+	var c ConcurrentMap
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 500 {
+				key := strconv.Itoa(j % 50)
+				c.Set(key, New((i+j)%2 == 0))
+				c.Get(key)
+				if j%7 == 0 {
+					c.Delete(key)
+				}
+				if j%100 == 0 {
+					c.Snapshot()
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if n := len(c.Snapshot()); n > 50 {
+		t.Errorf("Snapshot() has %d entries, want at most 50", n)
+	}
+}
+
+func BenchmarkConcurrentMapGet(b *testing.B) {
+	keys := make([]string, 1000)
+	m := Map{}
+	for i := range keys {
+		keys[i] = "flag_" + strconv.Itoa(i)
+		m[keys[i]] = New(i%2 == 0)
+	}
+	c := NewConcurrentMap(m)
+
+	b.Run("sharded", func(b *testing.B) {
+		b.SetParallelism(64)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				c.Get(keys[i%len(keys)])
+			}
+		})
+	})
+	b.Run("mutex", func(b *testing.B) {
+		var mu sync.RWMutex
+		b.SetParallelism(64)
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				mu.RLock()
+				_ = m[keys[i%len(keys)]]
+				mu.RUnlock()
+			}
+		})
+	})
+}
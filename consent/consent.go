@@ -0,0 +1,155 @@
+// Package consent records a subject's consent to a processing purpose on
+// top of tristate.TriState: True is granted, False is denied, and None is
+// not asked.
+//
+// A Record carries the policy version and time the answer was given and
+// encodes to flat JSON suited to audit exports:
+//
+//	{"subject":"u-42","purpose":"analytics","status":"granted",
+//	 "policy_version":"2024-05","recorded_at":"2024-06-01T12:00:00Z","source":"banner"}
+//
+// Consent that should no longer be relied on is expired by ExpiryRules,
+// which Effective applies before reporting the status.
+package consent
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tristate"
+)
+
+// Status words used in the JSON encoding.
+const (
+	Granted  = "granted"
+	Denied   = "denied"
+	NotAsked = "not_asked"
+)
+
+// Record is a subject's answer for one purpose.
+type Record struct {
+	Subject       string
+	Purpose       string
+	Value         tristate.TriState
+	PolicyVersion string    // Version of the policy text that was shown
+	RecordedAt    time.Time // When the answer was given
+	Source        string    // Where it was collected, e.g. "banner"
+	Actor         string    // Who recorded it, if not the subject
+}
+
+// Grant returns a Record of subject granting purpose under policyVersion
+// at time at.
+func Grant(subject, purpose, policyVersion string, at time.Time) Record {
+	return Record{Subject: subject, Purpose: purpose, Value: tristate.New(true), PolicyVersion: policyVersion, RecordedAt: at}
+}
+
+// Deny returns a Record of subject denying purpose under policyVersion at
+// time at.
+func Deny(subject, purpose, policyVersion string, at time.Time) Record {
+	return Record{Subject: subject, Purpose: purpose, Value: tristate.New(false), PolicyVersion: policyVersion, RecordedAt: at}
+}
+
+// Status returns Granted, Denied, or NotAsked.
+func (r Record) Status() string {
+	switch {
+	case r.Value.IsTrue():
+		return Granted
+	case r.Value.IsFalse():
+		return Denied
+	default:
+		return NotAsked
+	}
+}
+
+// ExpiryRule reports whether r should no longer be relied on at now.
+type ExpiryRule func(r Record, now time.Time) bool
+
+// MaxAge expires answers recorded more than d before now.
+func MaxAge(d time.Duration) ExpiryRule {
+	return func(r Record, now time.Time) bool { return now.Sub(r.RecordedAt) > d }
+}
+
+// RequireVersion expires answers given under a policy version other than
+// current, so subjects are asked again when the policy changes.
+func RequireVersion(current string) ExpiryRule {
+	return func(r Record, _ time.Time) bool { return r.PolicyVersion != current }
+}
+
+// GrantsOnly applies rule to granted answers only, so a denial stands
+// until the subject changes it.
+func GrantsOnly(rule ExpiryRule) ExpiryRule {
+	return func(r Record, now time.Time) bool { return r.Value.IsTrue() && rule(r, now) }
+}
+
+// Effective returns r's value at now, or None if any rule expires it, so
+// an expired answer reads as not asked.
+func (r Record) Effective(now time.Time, rules ...ExpiryRule) tristate.TriState {
+	if r.Value.IsNone() {
+		return r.Value
+	}
+	for _, expired := range rules {
+		if expired(r, now) {
+			return tristate.TriState{}
+		}
+	}
+	return r.Value
+}
+
+// Allowed reports whether processing may go ahead at now: only an
+// unexpired grant allows it.
+func (r Record) Allowed(now time.Time, rules ...ExpiryRule) bool {
+	return r.Effective(now, rules...).IsTrue()
+}
+
+type record struct {
+	Subject       string    `json:"subject"`
+	Purpose       string    `json:"purpose"`
+	Status        string    `json:"status"`
+	PolicyVersion string    `json:"policy_version,omitempty"`
+	RecordedAt    time.Time `json:"recorded_at,omitzero"`
+	Source        string    `json:"source,omitempty"`
+	Actor         string    `json:"actor,omitempty"`
+}
+
+// MarshalJSON encodes r as a flat object with its Status.
+func (r Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(record{
+		Subject:       r.Subject,
+		Purpose:       r.Purpose,
+		Status:        r.Status(),
+		PolicyVersion: r.PolicyVersion,
+		RecordedAt:    r.RecordedAt,
+		Source:        r.Source,
+		Actor:         r.Actor,
+	})
+}
+
+// UnmarshalJSON decodes the form produced by MarshalJSON. A missing status
+// is NotAsked.
+func (r *Record) UnmarshalJSON(data []byte) error {
+	var p record
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	var v tristate.TriState
+	switch p.Status {
+	case Granted:
+		v = tristate.New(true)
+	case Denied:
+		v = tristate.New(false)
+	case NotAsked, "":
+	default:
+		return fmt.Errorf("consent: invalid status %q", p.Status)
+	}
+	*r = Record{
+		Subject:       p.Subject,
+		Purpose:       p.Purpose,
+		Value:         v,
+		PolicyVersion: p.PolicyVersion,
+		RecordedAt:    p.RecordedAt,
+		Source:        p.Source,
+		Actor:         p.Actor,
+	}
+	return nil
+}
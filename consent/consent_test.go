@@ -0,0 +1,69 @@
+package consent
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"tristate"
+)
+
+var recorded = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestRecord_JSON(t *testing.T) {
+	r := Grant("u-42", "analytics", "2024-05", recorded)
+	r.Source = "banner"
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"subject":"u-42","purpose":"analytics","status":"granted","policy_version":"2024-05","recorded_at":"2024-06-01T12:00:00Z","source":"banner"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var back Record
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != r {
+		t.Errorf("Unmarshal = %+v, want %+v", back, r)
+	}
+
+	if data, _ := json.Marshal(Record{Subject: "u-1", Purpose: "ads"}); string(data) != `{"subject":"u-1","purpose":"ads","status":"not_asked"}` {
+		t.Errorf("Marshal(not asked) = %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"status":"maybe"}`), &back); err == nil {
+		t.Error("Unmarshal of an invalid status succeeded")
+	}
+}
+
+func TestRecord_Effective(t *testing.T) {
+	rules := []ExpiryRule{MaxAge(365 * 24 * time.Hour), RequireVersion("2024-05")}
+	soon, later := recorded.Add(24*time.Hour), recorded.Add(400*24*time.Hour)
+
+	tests := []struct {
+		name  string
+		r     Record
+		now   time.Time
+		rules []ExpiryRule
+		want  tristate.TriState
+	}{
+		{"Fresh grant", Grant("u", "ads", "2024-05", recorded), soon, rules, tristate.New(true)},
+		{"Old grant", Grant("u", "ads", "2024-05", recorded), later, rules, tristate.TriState{}},
+		{"Outdated policy", Grant("u", "ads", "2023-01", recorded), soon, rules, tristate.TriState{}},
+		{"Denial expires", Deny("u", "ads", "2024-05", recorded), later, rules, tristate.TriState{}},
+		{"Denial stands", Deny("u", "ads", "2024-05", recorded), later, []ExpiryRule{GrantsOnly(MaxAge(time.Hour))}, tristate.New(false)},
+		{"Not asked", Record{}, soon, rules, tristate.TriState{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Effective(tt.now, tt.rules...); got != tt.want {
+				t.Errorf("Effective() = %v, want %v", got, tt.want)
+			}
+			if got := tt.r.Allowed(tt.now, tt.rules...); got != tt.want.IsTrue() {
+				t.Errorf("Allowed() = %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package tristate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Key identifies a tri-state value carried by a context.Context, such as a
+// per-request dry-run, verbose, or force override. Keys are created once,
+// usually as package-level variables, with NewKey:
+//
+//	var DryRun = tristate.NewKey("dry_run")
+//
+//	ctx = tristate.WithValue(ctx, DryRun, tristate.New(true))
+//	if v, _ := tristate.FromContext(ctx, DryRun); v.IsTrue() { ... }
+//
+// Middleware stores request parameters under their Param's Key the same
+// way, so handlers read them with FromContext too.
+type Key struct {
+	name string
+}
+
+// Name returns the name the key was registered with.
+func (k *Key) Name() string { return k.name }
+
+// String returns the key's name.
+func (k *Key) String() string { return k.name }
+
+var keyRegistry struct {
+	mu   sync.Mutex
+	keys map[string]*Key
+}
+
+// NewKey registers and returns a Key called name. Names are global, so two
+// packages cannot unknowingly share a key; NewKey panics if name is
+// already registered.
+func NewKey(name string) *Key {
+	keyRegistry.mu.Lock()
+	defer keyRegistry.mu.Unlock()
+	if _, dup := keyRegistry.keys[name]; dup {
+		panic(fmt.Sprintf("tristate: duplicate context key %q", name))
+	}
+	if keyRegistry.keys == nil {
+		keyRegistry.keys = map[string]*Key{}
+	}
+	k := &Key{name: name}
+	keyRegistry.keys[name] = k
+	return k
+}
+
+// Keys returns every registered Key sorted by name.
+func Keys() []*Key {
+	keyRegistry.mu.Lock()
+	defer keyRegistry.mu.Unlock()
+	out := make([]*Key, 0, len(keyRegistry.keys))
+	for _, k := range keyRegistry.keys {
+		out = append(out, k)
+	}
+	slices.SortFunc(out, func(a, b *Key) int { return strings.Compare(a.name, b.name) })
+	return out
+}
+
+// WithValue returns a copy of ctx carrying v under key. Storing None
+// records an explicit None that hides a value set further out.
+func WithValue(ctx context.Context, key *Key, v TriState) context.Context {
+	return context.WithValue(ctx, key, v)
+}
+
+// FromContext returns the value stored under key and whether one was
+// found.
+func FromContext(ctx context.Context, key *Key) (TriState, bool) {
+	v, ok := ctx.Value(key).(TriState)
+	return v, ok
+}
+
+// ValuesFromContext returns the non-None values ctx carries for every
+// registered Key, keyed by name.
+func ValuesFromContext(ctx context.Context) Map {
+	out := Map{}
+	for _, k := range Keys() {
+		if v, _ := FromContext(ctx, k); !v.IsNone() {
+			out[k.name] = v
+		}
+	}
+	return out
+}
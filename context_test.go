@@ -0,0 +1,66 @@
+package tristate
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+)
+
+var (
+	testDryRun  = NewKey("test_dry_run")
+	testVerbose = NewKey("test_verbose")
+	testTrace   = NewKey("test_trace")
+)
+
+func TestFromContext(t *testing.T) {
+	base := WithValue(context.Background(), testVerbose, New(false))
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		key    *Key
+		want   TriState
+		wantOK bool
+	}{
+		{"Missing", context.Background(), testDryRun, TriState{}, false},
+		{"Stored", WithValue(context.Background(), testDryRun, New(true)), testDryRun, New(true), true},
+		{"Inner wins", WithValue(WithValue(context.Background(), testDryRun, New(true)), testDryRun, New(false)), testDryRun, New(false), true},
+		{"Explicit None", WithValue(context.Background(), testDryRun, TriState{}), testDryRun, TriState{}, true},
+		{"Outer value", base, testVerbose, New(false), true},
+		{"Hides outer value", WithValue(base, testVerbose, TriState{}), testVerbose, TriState{}, true},
+		{"Other key", base, testDryRun, TriState{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromContext(tt.ctx, tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FromContext() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValuesFromContext(t *testing.T) {
+	ctx := WithValue(WithValue(context.Background(), testDryRun, New(true)), testVerbose, TriState{})
+	if got, want := ValuesFromContext(ctx), (Map{"test_dry_run": New(true)}); !maps.Equal(got, want) {
+		t.Errorf("ValuesFromContext() = %v, want %v", got, want)
+	}
+	if got := ValuesFromContext(context.Background()); len(got) != 0 {
+		t.Errorf("ValuesFromContext() = %v, want empty", got)
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	if got := testDryRun.Name(); got != "test_dry_run" {
+		t.Errorf("Name() = %q", got)
+	}
+	if !slices.Contains(Keys(), testDryRun) || !slices.Contains(Keys(), testVerbose) {
+		t.Errorf("Keys() = %v, missing registered keys", Keys())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("NewKey did not panic on a duplicate name")
+		}
+	}()
+	NewKey("test_dry_run")
+}
@@ -0,0 +1,46 @@
+package tristate_test
+
+import (
+	"testing"
+	"time"
+
+	"tristate"
+	"tristate/tristatetest"
+)
+
+func TestObservable_OnChangeDebounced(t *testing.T) {
+	clock := tristatetest.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	o := &tristate.Observable{Clock: clock}
+	var got []tristate.Change
+	cancel := o.OnChangeDebounced(20*time.Millisecond, func(c tristate.Change) { got = append(got, c) })
+	defer cancel()
+
+	// A storm of flips is coalesced into one change from None to the end.
+	for i := range 10 {
+		o.Store(tristate.New(i%2 == 0))
+		clock.Advance(10 * time.Millisecond)
+	}
+	if len(got) != 0 {
+		t.Fatalf("received %+v before the storm settled", got)
+	}
+	clock.Advance(10 * time.Millisecond)
+	if want := (tristate.Change{Kind: tristate.Added, After: tristate.New(false)}); len(got) != 1 || got[0] != want {
+		t.Fatalf("received %+v, want [%+v]", got, want)
+	}
+
+	// A burst that returns to where it started delivers nothing.
+	o.Store(tristate.New(true))
+	o.Store(tristate.New(false))
+	clock.Advance(time.Second)
+	if len(got) != 1 {
+		t.Errorf("received %+v for a net-zero burst", got[1:])
+	}
+
+	// Cancel discards a pending notification.
+	o.Store(tristate.TriState{})
+	cancel()
+	clock.Advance(time.Second)
+	if len(got) != 1 {
+		t.Errorf("received %+v after cancel", got[1:])
+	}
+}
@@ -0,0 +1,65 @@
+package tristate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DecodeHook returns a mapstructure decode hook (a DecodeHookFuncType)
+// that converts configuration values into TriState:
+//
+//   - nil becomes None
+//   - bools become True or False
+//   - strings are parsed with Parse
+//   - the numbers 1 and 0, of any numeric kind, become True and False
+//
+// Any other input for a TriState target is an error. Values bound for other
+// types pass through unchanged. The hook depends only on reflect, so it can
+// be installed in any mapstructure-based loader:
+//
+//	cfg := &mapstructure.DecoderConfig{
+//		DecodeHook: tristate.DecodeHook(),
+//		Result:     &out,
+//	}
+func DecodeHook() func(from, to reflect.Type, data any) (any, error) {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if to != triStateType {
+			return data, nil
+		}
+		return decodeAny(data)
+	}
+}
+
+func decodeAny(data any) (TriState, error) {
+	switch v := data.(type) {
+	case nil:
+		return TriState{}, nil
+	case TriState:
+		return v, nil
+	case bool:
+		return New(v), nil
+	case string:
+		return Parse(v)
+	}
+
+	rv := reflect.ValueOf(data)
+	var n float64
+	switch {
+	case rv.CanInt():
+		n = float64(rv.Int())
+	case rv.CanUint():
+		n = float64(rv.Uint())
+	case rv.CanFloat():
+		n = rv.Float()
+	default:
+		return TriState{}, fmt.Errorf("cannot decode %T into tristate.TriState", data)
+	}
+	switch n {
+	case 1:
+		return New(true), nil
+	case 0:
+		return New(false), nil
+	default:
+		return TriState{}, fmt.Errorf("invalid tristate value: %v", data)
+	}
+}
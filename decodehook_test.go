@@ -0,0 +1,90 @@
+package tristate
+
+import (
+	"testing"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+type decodeTarget struct {
+	Bool    TriState  `mapstructure:"bool"`
+	String  TriState  `mapstructure:"string"`
+	Int     TriState  `mapstructure:"int"`
+	Float   TriState  `mapstructure:"float"`
+	Nil     TriState  `mapstructure:"nil"`
+	Missing TriState  `mapstructure:"missing"`
+	Pointer *TriState `mapstructure:"pointer"`
+	Flags   Map       `mapstructure:"flags"`
+}
+
+func decodeWithHook(input map[string]any, weak bool) (decodeTarget, error) {
+	var out decodeTarget
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       DecodeHook(),
+		WeaklyTypedInput: weak,
+		Result:           &out,
+	})
+	if err != nil {
+		return out, err
+	}
+	return out, dec.Decode(input)
+}
+
+func TestDecodeHook(t *testing.T) {
+	input := map[string]any{
+		"bool":    true,
+		"string":  "false",
+		"int":     1,
+		"float":   0.0,
+		"nil":     nil,
+		"pointer": "t",
+		"flags":   map[string]any{"a": uint8(0), "b": "none"},
+	}
+	for _, weak := range []bool{false, true} {
+		got, err := decodeWithHook(input, weak)
+		if err != nil {
+			t.Fatalf("Decode(weak=%v) failed: %v", weak, err)
+		}
+		tests := []struct {
+			name string
+			got  TriState
+			want State
+		}{
+			{"Bool", got.Bool, True},
+			{"String", got.String, False},
+			{"Int", got.Int, True},
+			{"Float", got.Float, False},
+			{"Nil", got.Nil, None},
+			{"Missing", got.Missing, None},
+			{"Map uint", got.Flags["a"], False},
+			{"Map none string", got.Flags["b"], None},
+		}
+		for _, tt := range tests {
+			if tt.got.value != tt.want {
+				t.Errorf("weak=%v %s: got %v, want %v", weak, tt.name, tt.got.value, tt.want)
+			}
+		}
+		if got.Pointer == nil || !got.Pointer.IsTrue() {
+			t.Errorf("weak=%v Pointer = %v, want True", weak, got.Pointer)
+		}
+	}
+}
+
+func TestDecodeHook_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+	}{
+		{"Unknown string", "sometimes"},
+		{"Out of range number", 2},
+		{"Slice", []any{true}},
+		{"Map", map[string]any{"x": true}},
+	}
+	for _, tt := range tests {
+		for _, weak := range []bool{false, true} {
+			if _, err := decodeWithHook(map[string]any{"bool": tt.input}, weak); err == nil {
+				t.Errorf("%s (weak=%v): Decode succeeded, want error", tt.name, weak)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package tristate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ApplyDefaults fills every None TriState field of the struct pointed to by
+// v from its `tristate:"default=..."` tag, recursing into nested structs,
+// embedded structs, and non-nil struct pointers. Fields that are already
+// set, or that have no default, are left alone. Traced fields receive the
+// default with Source "default".
+//
+// The default is parsed with Parse, so any boolean spelling it accepts may
+// be used.
+func ApplyDefaults(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tristate: ApplyDefaults requires a non-nil struct pointer, got %T", v)
+	}
+	return walkFields(rv.Elem(), "", func(field reflect.Value, sf reflect.StructField, path string) error {
+		opts, err := parseTag(sf.Tag.Get(tagName))
+		if err != nil {
+			return fmt.Errorf("tristate: field %s: %w", path, err)
+		}
+		if !opts.hasDefault {
+			return nil
+		}
+		switch cur := field.Addr().Interface().(type) {
+		case *TriState:
+			if cur.IsNone() {
+				*cur = opts.defaultValue
+			}
+		case *Traced:
+			if cur.Value.IsNone() {
+				*cur = Traced{Value: opts.defaultValue, Source: "default"}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,69 @@
+package tristate
+
+import "testing"
+
+type defaultsDB struct {
+	TLS TriState `tristate:"default=true"`
+}
+
+type defaultsConfig struct {
+	Audit   TriState `tristate:"default=true"`
+	Beta    TriState `tristate:"default=false"`
+	Tracing TriState `tristate:"default=1"`
+	Plain   TriState
+	Origin  Traced `tristate:"default=true"`
+	DB      defaultsDB
+	Replica *defaultsDB
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cfg := defaultsConfig{
+		Beta:    New(true),
+		Replica: &defaultsDB{},
+	}
+	if err := ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  TriState
+		want State
+	}{
+		{"None filled from tag", cfg.Audit, True},
+		{"Explicit value kept", cfg.Beta, True},
+		{"Alternate spelling", cfg.Tracing, True},
+		{"No tag stays None", cfg.Plain, None},
+		{"Traced", cfg.Origin.Value, True},
+		{"Nested struct", cfg.DB.TLS, True},
+		{"Nested pointer", cfg.Replica.TLS, True},
+	}
+	for _, tt := range tests {
+		if tt.got.value != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got.value, tt.want)
+		}
+	}
+	if cfg.Origin.Source != "default" {
+		t.Errorf("Origin.Source = %q, want default", cfg.Origin.Source)
+	}
+}
+
+func TestApplyDefaults_Errors(t *testing.T) {
+	var bad struct {
+		Flag TriState `tristate:"default=maybe"`
+	}
+	if err := ApplyDefaults(&bad); err == nil {
+		t.Error("ApplyDefaults accepted an invalid default")
+	}
+
+	var unknown struct {
+		Flag TriState `tristate:"dflt=true"`
+	}
+	if err := ApplyDefaults(&unknown); err == nil {
+		t.Error("ApplyDefaults accepted an unknown tag option")
+	}
+
+	if err := ApplyDefaults(defaultsConfig{}); err == nil {
+		t.Error("ApplyDefaults accepted a non-pointer")
+	}
+}
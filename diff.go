@@ -0,0 +1,75 @@
+package tristate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChangeKind classifies an entry reported by Diff.
+type ChangeKind uint8
+
+const (
+	Added   ChangeKind = iota + 1 // None before, explicitly set after
+	Removed                       // Explicitly set before, None after
+	Changed                       // Set before and after, to different values
+)
+
+// String returns "added", "removed", or "changed".
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", uint8(k))
+	}
+}
+
+// MarshalText encodes the kind as its String form, so Changes read well in
+// JSON audit logs.
+func (k ChangeKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// Change describes how a single key differs between two Maps.
+type Change struct {
+	Key    string     `json:"key"`
+	Kind   ChangeKind `json:"kind"`
+	Before TriState   `json:"before"`
+	After  TriState   `json:"after"`
+}
+
+// Diff reports every key whose value differs between before and after,
+// sorted by key. Missing keys are treated as None, so a key present in only
+// one Map with a None value is not a change. Use Map.Diff instead to get the
+// differences as a Map.
+func Diff(before, after Map) []Change {
+	var out []Change
+	for k, a := range after {
+		if b := before[k]; b != a {
+			out = append(out, newChange(k, b, a))
+		}
+	}
+	for k, b := range before {
+		if _, ok := after[k]; !ok && !b.IsNone() {
+			out = append(out, newChange(k, b, TriState{}))
+		}
+	}
+	slices.SortFunc(out, func(x, y Change) int { return strings.Compare(x.Key, y.Key) })
+	return out
+}
+
+func newChange(key string, before, after TriState) Change {
+	kind := Changed
+	switch {
+	case before.IsNone():
+		kind = Added
+	case after.IsNone():
+		kind = Removed
+	}
+	return Change{Key: key, Kind: kind, Before: before, After: after}
+}
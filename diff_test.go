@@ -0,0 +1,39 @@
+package tristate
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := Map{"same": New(true), "flip": New(true), "gone": New(false), "cleared": New(true), "idle": {}}
+	after := Map{"same": New(true), "flip": New(false), "cleared": {}, "new": New(true), "quiet": {}}
+
+	got := Diff(before, after)
+	want := []Change{
+		{Key: "cleared", Kind: Removed, Before: New(true), After: TriState{}},
+		{Key: "flip", Kind: Changed, Before: New(true), After: New(false)},
+		{Key: "gone", Kind: Removed, Before: New(false), After: TriState{}},
+		{Key: "new", Kind: Added, Before: TriState{}, After: New(true)},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+
+	if got := Diff(Map{"a": New(true)}, Map{"a": New(true)}); len(got) != 0 {
+		t.Errorf("Diff() of equal maps = %+v, want none", got)
+	}
+}
+
+func TestChange_JSON(t *testing.T) {
+	c := Change{Key: "beta", Kind: Added, After: New(true)}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"key":"beta","kind":"added","before":null,"after":true}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,58 @@
+package tristate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type echoRequest struct {
+	Archived TriState `param:"archived"`
+	Pinned   TriState `query:"pinned"`
+	Starred  TriState `form:"starred"`
+}
+
+func TestTriState_EchoBind(t *testing.T) {
+	tests := []struct {
+		name     string
+		archived string
+		target   string
+		body     string
+		want     [3]State
+		wantErr  bool
+	}{
+		{"All present", "true", "/?pinned=no", "starred=on", [3]State{True, False, True}, false},
+		{"Absent params", "none", "/", "", [3]State{None, None, None}, false},
+		{"Empty query", "0", "/?pinned=", "", [3]State{False, None, None}, false},
+		{"Invalid query", "1", "/?pinned=maybe", "", [3]State{}, true},
+	}
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			c := e.NewContext(req, httptest.NewRecorder())
+			c.SetParamNames("archived")
+			c.SetParamValues(tt.archived)
+
+			// Bind skips query parameters on POST, so bind them explicitly.
+			var r echoRequest
+			err := c.Bind(&r)
+			if err == nil {
+				err = (&echo.DefaultBinder{}).BindQueryParams(c, &r)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := [3]State{r.Archived.value, r.Pinned.value, r.Starred.value}; got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package tristate
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Vocabulary lists the words that mean True, False, and None when parsing
+// free-form input such as environment variables. Matching ignores case and
+// surrounding whitespace.
+type Vocabulary struct {
+	True  []string
+	False []string
+	None  []string
+}
+
+// DefaultVocabulary accepts the common spellings of on and off used in
+// environment variables.
+var DefaultVocabulary = Vocabulary{
+	True:  []string{"true", "t", "1", "yes", "y", "on", "enable", "enabled"},
+	False: []string{"false", "f", "0", "no", "n", "off", "disable", "disabled"},
+	None:  []string{"", "none", "null", "unset"},
+}
+
+// Parse converts s to a TriState using the vocabulary's words.
+func (v Vocabulary) Parse(s string) (TriState, error) {
+	raw := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	match := func(words []string) bool {
+		return slices.ContainsFunc(words, func(w string) bool { return strings.ToLower(w) == s })
+	}
+	switch {
+	case match(v.True):
+		return New(true), nil
+	case match(v.False):
+		return New(false), nil
+	case match(v.None):
+		return TriState{}, nil
+	default:
+		return TriState{}, reject("vocabulary", raw, fmt.Errorf("invalid tristate value: %q", s))
+	}
+}
+
+// EnvOption configures FromEnv and ScanEnv.
+type EnvOption func(*envOptions)
+
+type envOptions struct {
+	vocab Vocabulary
+}
+
+// WithVocabulary replaces DefaultVocabulary for parsing variable values.
+func WithVocabulary(v Vocabulary) EnvOption {
+	return func(o *envOptions) { o.vocab = v }
+}
+
+func newEnvOptions(opts []EnvOption) envOptions {
+	o := envOptions{vocab: DefaultVocabulary}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// FromEnv reads the environment variable name as a TriState. An unset
+// variable, or one whose value is not in the vocabulary, is None.
+func FromEnv(name string, opts ...EnvOption) TriState {
+	o := newEnvOptions(opts)
+	raw, ok := os.LookupEnv(name)
+	if !ok {
+		return TriState{}
+	}
+	v, _ := o.vocab.Parse(raw)
+	return v
+}
+
+// ScanEnv reads every environment variable starting with prefix into a
+// Map keyed by the rest of the variable name in lower case, so with prefix
+// "FEATURE_" the variable FEATURE_DARK_MODE=on yields "dark_mode": True.
+// Variables whose value is None or not in the vocabulary are skipped.
+func ScanEnv(prefix string, opts ...EnvOption) Map {
+	o := newEnvOptions(opts)
+	out := Map{}
+	for _, kv := range os.Environ() {
+		name, raw, _ := strings.Cut(kv, "=")
+		key, ok := strings.CutPrefix(name, prefix)
+		if !ok || key == "" {
+			continue
+		}
+		if v, err := o.vocab.Parse(raw); err == nil && !v.IsNone() {
+			out[strings.ToLower(key)] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package tristate
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestVocabulary_Parse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    State
+		wantErr bool
+	}{
+		{"yes", True, false},
+		{" ON ", True, false},
+		{"Enabled", True, false},
+		{"off", False, false},
+		{"N", False, false},
+		{"", None, false},
+		{"unset", None, false},
+		{"perhaps", None, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := DefaultVocabulary.Parse(tt.input)
+			if (err != nil) != tt.wantErr || got.value != tt.want {
+				t.Errorf("Parse(%q) = (%v, %v), want (%v, err=%v)", tt.input, got.value, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("TS_ON", "yes")
+	t.Setenv("TS_OFF", "off")
+	t.Setenv("TS_EMPTY", "")
+	t.Setenv("TS_BAD", "perhaps")
+	t.Setenv("TS_CUSTOM", "sí")
+
+	tests := []struct {
+		name string
+		opts []EnvOption
+		want State
+	}{
+		{"TS_ON", nil, True},
+		{"TS_OFF", nil, False},
+		{"TS_EMPTY", nil, None},
+		{"TS_BAD", nil, None},
+		{"TS_MISSING", nil, None},
+		{"TS_CUSTOM", []EnvOption{WithVocabulary(Vocabulary{True: []string{"sí"}, False: []string{"no"}})}, True},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromEnv(tt.name, tt.opts...).value; got != tt.want {
+				t.Errorf("FromEnv(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanEnv(t *testing.T) {
+	t.Setenv("TSFEATURE_DARK_MODE", "on")
+	t.Setenv("TSFEATURE_LEGACY", "false")
+	t.Setenv("TSFEATURE_INHERIT", "")
+	t.Setenv("TSFEATURE_BROKEN", "perhaps")
+	t.Setenv("TSFEATURE_", "true")
+	t.Setenv("OTHER_FLAG", "true")
+
+	got := ScanEnv("TSFEATURE_")
+	want := Map{"dark_mode": New(true), "legacy": New(false)}
+	if !maps.Equal(got, want) {
+		t.Errorf("ScanEnv() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package tristate
+
+import (
+	"testing"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+func TestTriState_Envconfig(t *testing.T) {
+	var spec struct {
+		Audit   TriState `envconfig:"AUDIT"`
+		Beta    TriState `envconfig:"BETA"`
+		Tracing TriState `envconfig:"TRACING"`
+		Unset   TriState `envconfig:"UNSET"`
+		Default TriState `envconfig:"DEFAULTED" default:"true"`
+	}
+	t.Setenv("APP_AUDIT", "true")
+	t.Setenv("APP_BETA", "0")
+	t.Setenv("APP_TRACING", "")
+
+	if err := envconfig.Process("app", &spec); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		got  TriState
+		want State
+	}{
+		{"Set true", spec.Audit, True},
+		{"Set false", spec.Beta, False},
+		{"Empty variable", spec.Tracing, None},
+		{"Unset variable", spec.Unset, None},
+		{"envconfig default", spec.Default, True},
+	}
+	for _, tt := range tests {
+		if tt.got.value != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got.value, tt.want)
+		}
+	}
+
+	t.Setenv("APP_AUDIT", "sometimes")
+	if err := envconfig.Process("app", &spec); err == nil {
+		t.Error("Process accepted an invalid value")
+	}
+}
@@ -0,0 +1,48 @@
+package tristate
+
+import (
+	"sync"
+	"time"
+)
+
+// Expiring is a TriState whose explicit value lapses after a time to live,
+// for temporary overrides such as emergency switches that must not
+// outlive an incident. Once expired it reads as Fallback. An Expiring is
+// safe for concurrent use; the zero value reads as None.
+type Expiring struct {
+	// Fallback is the value read when nothing is set or the set value has
+	// expired. It is None unless configured before use.
+	Fallback TriState
+
+	// Clock tells the time; nil means SystemClock.
+	Clock Clock
+
+	mu      sync.Mutex
+	value   TriState
+	expires time.Time
+}
+
+// Set stores v until ttl has elapsed. A non-positive ttl expires v at
+// once. Setting None clears any override before it expires.
+func (e *Expiring) Set(v TriState, ttl time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.value, e.expires = v, now(e.Clock).Add(ttl)
+}
+
+// Load returns the set value while it is live, and Fallback otherwise.
+func (e *Expiring) Load() TriState {
+	v, _ := e.Lookup()
+	return v
+}
+
+// Lookup returns the effective value and, if it is a live override, when
+// it expires. For Fallback, the returned time is zero.
+func (e *Expiring) Lookup() (TriState, time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.value.IsNone() || !now(e.Clock).Before(e.expires) {
+		return e.Fallback, time.Time{}
+	}
+	return e.value, e.expires
+}
@@ -0,0 +1,57 @@
+package tristate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiring(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	e := &Expiring{Clock: ClockFunc(func() time.Time { return now })}
+
+	if got := e.Load(); !got.IsNone() {
+		t.Errorf("zero Load() = %v", got)
+	}
+
+	e.Set(New(false), time.Hour)
+	if got, until := e.Lookup(); !got.IsFalse() || !until.Equal(now.Add(time.Hour)) {
+		t.Errorf("Lookup() = %v, %v", got, until)
+	}
+
+	now = now.Add(59 * time.Minute)
+	if got := e.Load(); !got.IsFalse() {
+		t.Errorf("Load() before expiry = %v", got)
+	}
+	now = now.Add(time.Minute)
+	if got, until := e.Lookup(); !got.IsNone() || !until.IsZero() {
+		t.Errorf("Lookup() at expiry = %v, %v", got, until)
+	}
+
+	e.Set(New(true), 0)
+	if got := e.Load(); !got.IsNone() {
+		t.Errorf("Load() after zero ttl = %v", got)
+	}
+}
+
+func TestExpiring_Fallback(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	e := &Expiring{Fallback: New(true), Clock: ClockFunc(func() time.Time { return now })}
+	if got := e.Load(); !got.IsTrue() {
+		t.Errorf("unset Load() = %v, want fallback", got)
+	}
+
+	e.Set(New(false), time.Minute)
+	if got := e.Load(); !got.IsFalse() {
+		t.Errorf("Load() = %v, want override", got)
+	}
+	e.Set(TriState{}, time.Hour)
+	if got := e.Load(); !got.IsTrue() {
+		t.Errorf("Load() after clearing = %v, want fallback", got)
+	}
+
+	e.Set(New(false), time.Minute)
+	now = now.Add(2 * time.Minute)
+	if got := e.Load(); !got.IsTrue() {
+		t.Errorf("Load() after expiry = %v, want fallback", got)
+	}
+}
@@ -0,0 +1,20 @@
+package tristate
+
+import "expvar"
+
+// Func implements expvar.Var by calling the function each time /debug/vars
+// is served, reporting true, false, or null. It mirrors expvar.Func for
+// values that change at runtime.
+type Func func() TriState
+
+// String returns the current value encoded as JSON, as expvar requires.
+func (f Func) String() string {
+	b, _ := f().MarshalJSON()
+	return string(b)
+}
+
+// Publish exposes a under name in /debug/vars. Like expvar.Publish, it
+// panics if name is already registered.
+func Publish(name string, a *Atomic) {
+	expvar.Publish(name, Func(a.Load))
+}
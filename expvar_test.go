@@ -0,0 +1,38 @@
+package tristate
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestFunc_String(t *testing.T) {
+	tests := []struct {
+		v    TriState
+		want string
+	}{
+		{New(true), "true"},
+		{New(false), "false"},
+		{TriState{}, "null"},
+	}
+	for _, tt := range tests {
+		if got := Func(func() TriState { return tt.v }).String(); got != tt.want {
+			t.Errorf("Func(%v).String() = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	a := NewAtomic(New(true))
+	Publish("tristate_test_flag", a)
+	v := expvar.Get("tristate_test_flag")
+	if v == nil {
+		t.Fatal("variable not published")
+	}
+	if got := v.String(); got != "true" {
+		t.Errorf("String() = %q, want %q", got, "true")
+	}
+	a.Store(TriState{})
+	if got := v.String(); got != "null" {
+		t.Errorf("String() after Store = %q, want %q", got, "null")
+	}
+}
@@ -0,0 +1,223 @@
+// Package featureflag provides dependency-light feature flags built on
+// tristate.TriState.
+//
+// A flag has a default value. Overrides are set per target (an environment,
+// a tenant, or a user) and may be True, False, or None; None means "no
+// opinion" and defers to the next target. Evaluation consults the user,
+// then the tenant, then the environment of the evaluation Context, and
+// falls back to the flag's default.
+//
+// A flag with a Rollout percentage is rolled out gradually: when no
+// override decides it, a user is enabled if a hash of the flag name and
+// user ID falls within the percentage, and disabled otherwise, whatever
+// the flag's default. The hash is stable, so each user stays in or out of
+// the rollout as the percentage grows.
+package featureflag
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"tristate"
+)
+
+// ErrUnknownFlag is returned when a flag name has not been defined.
+var ErrUnknownFlag = errors.New("featureflag: unknown flag")
+
+// ErrInvalidRollout is returned by Define for a Rollout outside [0, 100].
+var ErrInvalidRollout = errors.New("featureflag: rollout must be between 0 and 100")
+
+// Flag is the definition of a feature flag.
+type Flag struct {
+	Name        string
+	Description string
+	Default     bool
+	// Rollout is the percentage of users, from 0 to 100, for whom the flag
+	// is on when no override decides it; it is off for the rest, and
+	// Default applies only to evaluations without a User. Zero disables
+	// the rollout.
+	Rollout float64
+}
+
+func (f Flag) validate() error {
+	if !(f.Rollout >= 0 && f.Rollout <= 100) {
+		return fmt.Errorf("%w: %q has %v", ErrInvalidRollout, f.Name, f.Rollout)
+	}
+	return nil
+}
+
+// inRollout reports whether user falls within the flag's Rollout.
+func (f Flag) inRollout(user string) bool {
+	h := fnv.New64a()
+	h.Write([]byte(f.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(user))
+	return float64(h.Sum64()%10000) < f.Rollout*100
+}
+
+// TargetKind is the kind of entity an override applies to.
+type TargetKind uint8
+
+const (
+	EnvironmentTarget TargetKind = iota + 1
+	TenantTarget
+	UserTarget
+)
+
+// String returns "environment", "tenant", or "user".
+func (k TargetKind) String() string {
+	switch k {
+	case EnvironmentTarget:
+		return "environment"
+	case TenantTarget:
+		return "tenant"
+	case UserTarget:
+		return "user"
+	default:
+		return fmt.Sprintf("TargetKind(%d)", uint8(k))
+	}
+}
+
+// Target identifies the entity an override applies to.
+type Target struct {
+	Kind TargetKind
+	ID   string
+}
+
+func Environment(name string) Target { return Target{Kind: EnvironmentTarget, ID: name} }
+func Tenant(id string) Target        { return Target{Kind: TenantTarget, ID: id} }
+func User(id string) Target          { return Target{Kind: UserTarget, ID: id} }
+
+// Context describes who a flag is being evaluated for. Empty fields are
+// skipped during evaluation.
+type Context struct {
+	User        string
+	Tenant      string
+	Environment string
+}
+
+// targets returns the Context's targets in evaluation order.
+func (c Context) targets() []Target {
+	var out []Target
+	if c.User != "" {
+		out = append(out, User(c.User))
+	}
+	if c.Tenant != "" {
+		out = append(out, Tenant(c.Tenant))
+	}
+	if c.Environment != "" {
+		out = append(out, Environment(c.Environment))
+	}
+	return out
+}
+
+// Result explains how a flag evaluated.
+type Result struct {
+	Value bool
+	// Target is the override that decided the value. It is the zero Target
+	// when the flag's default or rollout was used.
+	Target Target
+	// Rollout reports whether the flag's Rollout decided the value, to on
+	// or off.
+	Rollout bool
+}
+
+// IsDefault reports whether the flag's default decided the value.
+func (r Result) IsDefault() bool { return r.Target == Target{} && !r.Rollout }
+
+// Set holds flag definitions and their overrides. It is safe for
+// concurrent use.
+type Set struct {
+	mu        sync.RWMutex
+	flags     map[string]Flag
+	overrides map[Target]tristate.Map
+}
+
+// New returns a Set holding the given flag definitions. It panics if a
+// definition is invalid; use Define to check definitions built at run
+// time.
+func New(flags ...Flag) *Set {
+	s := &Set{
+		flags:     make(map[string]Flag),
+		overrides: make(map[Target]tristate.Map),
+	}
+	for _, f := range flags {
+		if err := f.validate(); err != nil {
+			panic(err)
+		}
+		s.flags[f.Name] = f
+	}
+	return s
+}
+
+// Define adds or replaces a flag definition. Existing overrides are kept.
+// It returns ErrInvalidRollout, leaving the Set unchanged, if f.Rollout is
+// out of range.
+func (s *Set) Define(f Flag) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flags[f.Name] = f
+	return nil
+}
+
+// Override sets the value of flag for target. Passing None clears the
+// override.
+func (s *Set) Override(flag string, target Target, v tristate.TriState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.flags[flag]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownFlag, flag)
+	}
+	m := s.overrides[target]
+	if v.IsNone() {
+		delete(m, flag)
+		if len(m) == 0 {
+			delete(s.overrides, target)
+		}
+		return nil
+	}
+	if m == nil {
+		m = tristate.Map{}
+		s.overrides[target] = m
+	}
+	m[flag] = v
+	return nil
+}
+
+// Overrides returns a copy of the overrides set for target.
+func (s *Set) Overrides(target Target) tristate.Map {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return tristate.Map{}.MergeOverride(s.overrides[target])
+}
+
+// Detail evaluates flag for ctx and reports which target decided it.
+func (s *Set) Detail(ctx Context, flag string) (Result, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f, ok := s.flags[flag]
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %q", ErrUnknownFlag, flag)
+	}
+	for _, t := range ctx.targets() {
+		if v, ok := s.overrides[t][flag].Bool(); ok {
+			return Result{Value: v, Target: t}, nil
+		}
+	}
+	if f.Rollout > 0 && ctx.User != "" {
+		return Result{Value: f.inRollout(ctx.User), Rollout: true}, nil
+	}
+	return Result{Value: f.Default}, nil
+}
+
+// Evaluate returns the value of flag for ctx. Unknown flags evaluate to
+// false; use Detail to distinguish them.
+func (s *Set) Evaluate(ctx Context, flag string) bool {
+	r, _ := s.Detail(ctx, flag)
+	return r.Value
+}
@@ -0,0 +1,148 @@
+package featureflag
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"tristate"
+)
+
+func TestSet_Evaluate(t *testing.T) {
+	s := New(
+		Flag{Name: "new-checkout", Default: false},
+		Flag{Name: "audit-log", Default: true},
+	)
+	mustOverride := func(flag string, target Target, v tristate.TriState) {
+		t.Helper()
+		if err := s.Override(flag, target, v); err != nil {
+			t.Fatalf("Override failed: %v", err)
+		}
+	}
+	mustOverride("new-checkout", Environment("staging"), tristate.New(true))
+	mustOverride("new-checkout", Tenant("acme"), tristate.New(false))
+	mustOverride("new-checkout", User("alice"), tristate.New(true))
+	mustOverride("audit-log", Tenant("acme"), tristate.New(false))
+
+	tests := []struct {
+		name       string
+		ctx        Context
+		flag       string
+		want       bool
+		wantTarget Target
+	}{
+		{"Default", Context{Environment: "prod"}, "new-checkout", false, Target{}},
+		{"Environment override", Context{Environment: "staging"}, "new-checkout", true, Environment("staging")},
+		{"Tenant beats environment", Context{Tenant: "acme", Environment: "staging"}, "new-checkout", false, Tenant("acme")},
+		{"User beats tenant", Context{User: "alice", Tenant: "acme"}, "new-checkout", true, User("alice")},
+		{"Unrelated user falls through", Context{User: "bob", Tenant: "acme"}, "new-checkout", false, Tenant("acme")},
+		{"Default true", Context{User: "alice"}, "audit-log", true, Target{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Evaluate(tt.ctx, tt.flag); got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+			r, err := s.Detail(tt.ctx, tt.flag)
+			if err != nil {
+				t.Fatalf("Detail failed: %v", err)
+			}
+			if r.Target != tt.wantTarget || r.IsDefault() != (tt.wantTarget == Target{}) {
+				t.Errorf("Detail().Target = %+v, want %+v", r.Target, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestSet_ClearOverride(t *testing.T) {
+	s := New(Flag{Name: "beta"})
+	s.Override("beta", User("alice"), tristate.New(true))
+	if !s.Evaluate(Context{User: "alice"}, "beta") {
+		t.Fatal("override not applied")
+	}
+	s.Override("beta", User("alice"), tristate.TriState{})
+	if s.Evaluate(Context{User: "alice"}, "beta") {
+		t.Error("clearing the override did not restore the default")
+	}
+	if len(s.Overrides(User("alice"))) != 0 {
+		t.Error("cleared override still listed")
+	}
+}
+
+func TestSet_UnknownFlag(t *testing.T) {
+	s := New()
+	if s.Evaluate(Context{}, "ghost") {
+		t.Error("unknown flag evaluated to true")
+	}
+	if _, err := s.Detail(Context{}, "ghost"); !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("Detail error = %v, want ErrUnknownFlag", err)
+	}
+	if err := s.Override("ghost", User("alice"), tristate.New(true)); !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("Override error = %v, want ErrUnknownFlag", err)
+	}
+}
+
+func TestSet_Rollout(t *testing.T) {
+	s := New(
+		Flag{Name: "new-search", Rollout: 25},
+		Flag{Name: "new-search-on", Default: true, Rollout: 25},
+		Flag{Name: "everyone", Rollout: 100},
+	)
+	s.Override("new-search", User("alice"), tristate.New(false))
+	s.Override("new-search", User("bob"), tristate.New(true))
+
+	for _, flag := range []string{"new-search", "new-search-on"} {
+		on := 0
+		for i := range 1000 {
+			user := fmt.Sprintf("user-%d", i)
+			r, err := s.Detail(Context{User: user}, flag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Rollout || r.IsDefault() {
+				t.Errorf("Detail(%s, %s) = %+v, want decided by rollout", user, flag, r)
+			}
+			if r.Value {
+				on++
+			}
+			if again := s.Evaluate(Context{User: user}, flag); again != r.Value {
+				t.Errorf("Evaluate(%s, %s) is not deterministic", user, flag)
+			}
+			if !s.Evaluate(Context{User: user}, "everyone") {
+				t.Errorf("Evaluate(%s) at 100%% = false", user)
+			}
+		}
+		if on < 200 || on > 300 {
+			t.Errorf("%s: %d of 1000 users in a 25%% rollout", flag, on)
+		}
+	}
+
+	for user, want := range map[string]bool{"alice": false, "bob": true} {
+		if r, _ := s.Detail(Context{User: user}, "new-search"); r.Value != want || r.Rollout {
+			t.Errorf("Detail(%s) = %+v, want override %v", user, r, want)
+		}
+	}
+	if r, _ := s.Detail(Context{Tenant: "acme"}, "new-search-on"); !r.Value || !r.IsDefault() {
+		t.Errorf("Detail() without a user = %+v, want default", r)
+	}
+}
+
+func TestSet_InvalidRollout(t *testing.T) {
+	s := New(Flag{Name: "beta", Rollout: 10})
+	for _, pct := range []float64{-1, 100.5, math.NaN()} {
+		if err := s.Define(Flag{Name: "beta", Rollout: pct}); !errors.Is(err, ErrInvalidRollout) {
+			t.Errorf("Define(Rollout: %v) error = %v, want ErrInvalidRollout", pct, err)
+		}
+	}
+	if err := s.Define(Flag{Name: "beta", Rollout: 100}); err != nil {
+		t.Errorf("Define(Rollout: 100) failed: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("New with an invalid Rollout did not panic")
+		}
+	}()
+	New(Flag{Name: "beta", Rollout: 200})
+}
@@ -0,0 +1,96 @@
+package tristate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ApplyWithFieldMask copies the fields named by mask from src to dst, with
+// the semantics of a google.protobuf.FieldMask in an Update RPC. Paths are
+// dot-separated JSON field names, e.g. "limits.burst", which match the
+// names protoc-gen-go emits in json tags. A path naming a Map field may
+// address a single entry, e.g. "flags.beta".
+//
+// A masked field is always copied, so a TriState that is None in src clears
+// the field in dst; fields outside the mask are never touched. A path that
+// names a nested struct copies it whole. An empty mask falls back to
+// MergeStructs, updating only the TriState fields set in src.
+//
+// dst must be a non-nil pointer to a struct, and src a struct of the same
+// type or a pointer to one.
+func ApplyWithFieldMask(dst, src any, mask []string) error {
+	if len(mask) == 0 {
+		return MergeStructs(dst, src)
+	}
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tristate: ApplyWithFieldMask dst must be a non-nil struct pointer, got %T", dst)
+	}
+	sv := reflect.Indirect(reflect.ValueOf(src))
+	if !sv.IsValid() || sv.Type() != dv.Elem().Type() {
+		return fmt.Errorf("tristate: ApplyWithFieldMask type mismatch: dst %T, src %T", dst, src)
+	}
+	sv = addressable(sv)
+
+	for _, path := range mask {
+		if err := copyMaskedPath(dv.Elem(), sv, strings.Split(path, ".")); err != nil {
+			return fmt.Errorf("tristate: field mask path %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func copyMaskedPath(dst, src reflect.Value, path []string) error {
+	for i, name := range path {
+		if dst.Type() == mapType && i == len(path)-1 {
+			m := dst.Interface().(Map)
+			v := src.Interface().(Map)[name]
+			if v.IsNone() {
+				delete(m, name)
+				return nil
+			}
+			if m == nil {
+				m = Map{}
+				dst.Set(reflect.ValueOf(m))
+			}
+			m[name] = v
+			return nil
+		}
+		if dst.Kind() == reflect.Pointer {
+			if src.IsNil() {
+				// Nothing beneath an unset message: clear the masked field.
+				return clearMaskedPath(dst, path[i:])
+			}
+			if dst.IsNil() {
+				dst.Set(reflect.New(dst.Type().Elem()))
+			}
+			dst, src = dst.Elem(), src.Elem()
+		}
+		if dst.Kind() != reflect.Struct || !isPlainStruct(dst.Type()) {
+			return fmt.Errorf("%q is not a message field", name)
+		}
+		df, ok := jsonFields(dst)[name]
+		if !ok {
+			return fmt.Errorf("no field %q", name)
+		}
+		dst, src = df, jsonFields(src)[name]
+	}
+	if src.Kind() == reflect.Pointer && !src.IsNil() {
+		// Copy the message rather than aliasing src's.
+		cp := reflect.New(src.Type().Elem())
+		cp.Elem().Set(src.Elem())
+		src = cp
+	}
+	dst.Set(src)
+	return nil
+}
+
+// clearMaskedPath resets the field addressed by path beneath the pointer
+// dst, if dst leads to it.
+func clearMaskedPath(dst reflect.Value, path []string) error {
+	if dst.IsNil() {
+		return nil
+	}
+	return copyMaskedPath(dst, reflect.New(dst.Type().Elem()), path)
+}
@@ -0,0 +1,116 @@
+package tristate
+
+import "testing"
+
+type maskMessage struct {
+	Audit  TriState     `json:"audit,omitempty"`
+	Beta   TriState     `json:"beta,omitempty"`
+	Name   string       `json:"name,omitempty"`
+	Flags  Map          `json:"flags,omitempty"`
+	Limits *patchLimits `json:"limits,omitempty"`
+}
+
+func TestApplyWithFieldMask(t *testing.T) {
+	base := func() maskMessage {
+		return maskMessage{
+			Audit:  New(true),
+			Beta:   New(true),
+			Name:   "svc",
+			Flags:  Map{"a": New(true), "b": New(true)},
+			Limits: &patchLimits{Burst: New(true), Rate: 5},
+		}
+	}
+	src := maskMessage{
+		Audit:  New(false),
+		Name:   "api",
+		Flags:  Map{"b": New(false)},
+		Limits: &patchLimits{Rate: 9},
+	}
+
+	tests := []struct {
+		name  string
+		mask  []string
+		check func(t *testing.T, got maskMessage)
+	}{
+		{"Masked set field", []string{"audit"}, func(t *testing.T, got maskMessage) {
+			if !got.Audit.IsFalse() || !got.Beta.IsTrue() || got.Name != "svc" {
+				t.Errorf("got %+v", got)
+			}
+		}},
+		{"Masked unset field clears", []string{"beta"}, func(t *testing.T, got maskMessage) {
+			if !got.Beta.IsNone() || !got.Audit.IsTrue() {
+				t.Errorf("got %+v", got)
+			}
+		}},
+		{"Nested path", []string{"limits.burst"}, func(t *testing.T, got maskMessage) {
+			if !got.Limits.Burst.IsNone() || got.Limits.Rate != 5 {
+				t.Errorf("Limits = %+v", got.Limits)
+			}
+		}},
+		{"Whole message", []string{"limits"}, func(t *testing.T, got maskMessage) {
+			if *got.Limits != *src.Limits {
+				t.Errorf("Limits = %+v, want %+v", got.Limits, src.Limits)
+			}
+			if got.Limits == src.Limits {
+				t.Error("Limits aliases the source message")
+			}
+		}},
+		{"Map entry", []string{"flags.a", "flags.b"}, func(t *testing.T, got maskMessage) {
+			_, hasA := got.Flags["a"]
+			if hasA || !got.Flags["b"].IsFalse() {
+				t.Errorf("Flags = %v", got.Flags)
+			}
+		}},
+		{"Non-tristate field", []string{"name"}, func(t *testing.T, got maskMessage) {
+			if got.Name != "api" {
+				t.Errorf("Name = %q", got.Name)
+			}
+		}},
+		{"Empty mask merges set fields", nil, func(t *testing.T, got maskMessage) {
+			if !got.Audit.IsFalse() || !got.Beta.IsTrue() || got.Name != "svc" {
+				t.Errorf("got %+v", got)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := base()
+			if err := ApplyWithFieldMask(&got, &src, tt.mask); err != nil {
+				t.Fatalf("ApplyWithFieldMask failed: %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestApplyWithFieldMask_NilSourceMessage(t *testing.T) {
+	got := maskMessage{Limits: &patchLimits{Burst: New(true), Rate: 5}}
+	if err := ApplyWithFieldMask(&got, maskMessage{}, []string{"limits.burst"}); err != nil {
+		t.Fatalf("ApplyWithFieldMask failed: %v", err)
+	}
+	if !got.Limits.Burst.IsNone() || got.Limits.Rate != 5 {
+		t.Errorf("Limits = %+v, want burst cleared", got.Limits)
+	}
+}
+
+func TestApplyWithFieldMask_Errors(t *testing.T) {
+	var msg maskMessage
+	tests := []struct {
+		name string
+		dst  any
+		src  any
+		mask []string
+	}{
+		{"Unknown path", &msg, msg, []string{"nope"}},
+		{"Path through scalar", &msg, msg, []string{"name.x"}},
+		{"Type mismatch", &msg, patchLimits{}, []string{"audit"}},
+		{"Non-pointer dst", msg, msg, []string{"audit"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ApplyWithFieldMask(tt.dst, tt.src, tt.mask); err == nil {
+				t.Error("ApplyWithFieldMask succeeded, want error")
+			}
+		})
+	}
+}
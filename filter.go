@@ -0,0 +1,144 @@
+package tristate
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Is returns a predicate reporting whether a value is in the given state,
+// for use with Filter and Partition.
+func Is(state State) func(TriState) bool {
+	return func(v TriState) bool { return v.value == state }
+}
+
+// --- Plain Slices ---
+
+// Filter returns the values for which keep reports true, in order.
+func Filter(vals []TriState, keep func(TriState) bool) []TriState {
+	var out []TriState
+	for _, v := range vals {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Partition splits vals into those for which pred reports true and the
+// rest, preserving order within each.
+func Partition(vals []TriState, pred func(TriState) bool) (matched, rest []TriState) {
+	for _, v := range vals {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// IndexesOf returns the indexes of vals holding state, in ascending order.
+func IndexesOf(vals []TriState, state State) []int {
+	var out []int
+	for i, v := range vals {
+		if v.value == state {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// --- Packed Slices ---
+
+// Filter returns a new Slice holding the elements for which keep reports
+// true, in order.
+func (s *Slice) Filter(keep func(TriState) bool) Slice {
+	var out Slice
+	for v := range s.Values() {
+		if keep(v) {
+			out.Append(v)
+		}
+	}
+	return out
+}
+
+// Partition splits s into the elements for which pred reports true and the
+// rest, preserving order within each.
+func (s *Slice) Partition(pred func(TriState) bool) (matched, rest Slice) {
+	for v := range s.Values() {
+		if pred(v) {
+			matched.Append(v)
+		} else {
+			rest.Append(v)
+		}
+	}
+	return matched, rest
+}
+
+// IndexesOf returns the indexes of s holding state, in ascending order. It
+// scans a whole word at a time.
+func (s *Slice) IndexesOf(state State) []int {
+	var out []int
+	for wi, w := range s.words {
+		t, f := splitWord(w)
+		var match uint64
+		switch state {
+		case True:
+			match = t
+		case False:
+			match = f
+		case None:
+			match = ^(t | f) & lowBits
+		}
+		for match != 0 {
+			i := wi*valuesPerWord + bits.TrailingZeros64(match)/bitsPerValue
+			if i >= s.n {
+				break
+			}
+			out = append(out, i)
+			match &= match - 1
+		}
+	}
+	return out
+}
+
+// --- Maps ---
+
+// Filter returns a new Map holding the entries whose value keep reports
+// true for.
+func (m Map) Filter(keep func(TriState) bool) Map {
+	out := Map{}
+	for k, v := range m {
+		if keep(v) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Partition splits m into the entries for which pred reports true and the
+// rest.
+func (m Map) Partition(pred func(TriState) bool) (matched, rest Map) {
+	matched, rest = Map{}, Map{}
+	for k, v := range m {
+		if pred(v) {
+			matched[k] = v
+		} else {
+			rest[k] = v
+		}
+	}
+	return matched, rest
+}
+
+// KeysOf returns the sorted keys of m holding state. It is the Map
+// counterpart of IndexesOf.
+func (m Map) KeysOf(state State) []string {
+	var out []string
+	for k, v := range m {
+		if v.value == state {
+			out = append(out, k)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,78 @@
+package tristate
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestFilterPartition(t *testing.T) {
+	vals := []TriState{New(true), {}, New(false), {}, New(false)}
+
+	if got := Filter(vals, Is(False)); len(got) != 2 || !got[0].IsFalse() {
+		t.Errorf("Filter(Is(False)) = %v, want two False values", got)
+	}
+
+	matched, rest := Partition(vals, Is(None))
+	if len(matched) != 2 || len(rest) != 3 || !rest[0].IsTrue() {
+		t.Errorf("Partition(Is(None)) = (%v, %v)", matched, rest)
+	}
+
+	tests := []struct {
+		state State
+		want  []int
+	}{
+		{True, []int{0}},
+		{False, []int{2, 4}},
+		{None, []int{1, 3}},
+	}
+	for _, tt := range tests {
+		if got := IndexesOf(vals, tt.state); !slices.Equal(got, tt.want) {
+			t.Errorf("IndexesOf(%v) = %v, want %v", tt.state, got, tt.want)
+		}
+	}
+}
+
+func TestSlice_FilterPartition(t *testing.T) {
+	var vals []TriState
+	for i := 0; i < 70; i++ {
+		vals = append(vals, TriState{value: State(i % 3)})
+	}
+	s := SliceOf(vals...)
+
+	for _, state := range []State{None, False, True} {
+		if got, want := s.IndexesOf(state), IndexesOf(vals, state); !slices.Equal(got, want) {
+			t.Errorf("IndexesOf(%v) = %v, want %v", state, got, want)
+		}
+	}
+
+	filtered := s.Filter(Is(True))
+	if filtered.Len() != len(Filter(vals, Is(True))) {
+		t.Errorf("Filter(Is(True)).Len() = %d", filtered.Len())
+	}
+
+	matched, rest := s.Partition(Is(None))
+	if matched.Len()+rest.Len() != s.Len() || matched.Len() != 24 {
+		t.Errorf("Partition lengths = (%d, %d)", matched.Len(), rest.Len())
+	}
+	if !rest.Get(0).IsFalse() || !rest.Get(1).IsTrue() {
+		t.Error("Partition did not preserve order")
+	}
+}
+
+func TestMap_FilterPartition(t *testing.T) {
+	m := Map{"a": New(false), "b": {}, "c": New(true), "d": New(false)}
+
+	if got, want := m.Filter(Is(False)), (Map{"a": New(false), "d": New(false)}); !maps.Equal(got, want) {
+		t.Errorf("Filter(Is(False)) = %v, want %v", got, want)
+	}
+
+	matched, rest := m.Partition(Is(None))
+	if !maps.Equal(matched, Map{"b": {}}) || len(rest) != 3 {
+		t.Errorf("Partition(Is(None)) = (%v, %v)", matched, rest)
+	}
+
+	if got := m.KeysOf(False); !slices.Equal(got, []string{"a", "d"}) {
+		t.Errorf("KeysOf(False) = %v, want [a d]", got)
+	}
+}
@@ -0,0 +1,67 @@
+package tristate
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// RegisterBoolPair defines the GNU-style flag pair -name and -no-name on fs,
+// both writing target. -name sets True and -no-name sets False; both also
+// accept an explicit bool, so -no-name=false sets True. If neither appears,
+// target keeps its value, which is None for a zero TriState. Passing both
+// makes fs.Parse fail.
+func RegisterBoolPair(fs *flag.FlagSet, name string, target *TriState, usage string) {
+	p := &boolPair{name: name, target: target}
+	fs.Var(&boolPairFlag{pair: p, positive: true}, name, usage)
+	fs.Var(&boolPairFlag{pair: p}, "no-"+name, "disable: "+usage)
+}
+
+// boolPair is the state shared by the two halves of a RegisterBoolPair.
+type boolPair struct {
+	name   string
+	target *TriState
+	// seenPos and seenNeg record which halves have been passed.
+	seenPos, seenNeg bool
+}
+
+// boolPairFlag is one half of a RegisterBoolPair, implementing flag.Value.
+type boolPairFlag struct {
+	pair     *boolPair
+	positive bool
+}
+
+func (f *boolPairFlag) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("invalid bool value: %q", s)
+	}
+	p := f.pair
+	if f.positive && p.seenNeg || !f.positive && p.seenPos {
+		return fmt.Errorf("-%s and -no-%s are mutually exclusive", p.name, p.name)
+	}
+	if f.positive {
+		p.seenPos = true
+	} else {
+		p.seenNeg = true
+	}
+	*p.target = New(b == f.positive)
+	return nil
+}
+
+// String reports the target's value for the positive flag and its negation
+// for -no-name. The flag package may call it on a zero value.
+func (f *boolPairFlag) String() string {
+	if f.pair == nil {
+		return ""
+	}
+	v := *f.pair.target
+	if !f.positive {
+		if b, ok := v.Bool(); ok {
+			v = New(!b)
+		}
+	}
+	return v.String()
+}
+
+func (f *boolPairFlag) IsBoolFlag() bool { return true }
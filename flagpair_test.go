@@ -0,0 +1,61 @@
+package tristate
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegisterBoolPair(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    State
+		wantErr string
+	}{
+		{"Neither", nil, None, ""},
+		{"Positive", []string{"-audit"}, True, ""},
+		{"Negative", []string{"--no-audit"}, False, ""},
+		{"Explicit", []string{"-audit=false"}, False, ""},
+		{"Negated explicit", []string{"-no-audit=false"}, True, ""},
+		{"Repeated", []string{"-audit", "-audit"}, True, ""},
+		{"Both", []string{"-audit", "-no-audit"}, True, "mutually exclusive"},
+		{"Invalid", []string{"-audit=sometimes"}, None, "invalid bool value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var audit TriState
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			RegisterBoolPair(fs, "audit", &audit, "enable auditing")
+			err := fs.Parse(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if audit.value != tt.want {
+				t.Errorf("audit = %v, want %v", audit.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterBoolPair_Usage(t *testing.T) {
+	var audit TriState
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var out strings.Builder
+	fs.SetOutput(&out)
+	RegisterBoolPair(fs, "audit", &audit, "enable auditing")
+	fs.PrintDefaults()
+	for _, want := range []string{"-audit", "-no-audit", "disable: enable auditing"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("usage missing %q:\n%s", want, out.String())
+		}
+	}
+}
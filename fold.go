@@ -0,0 +1,73 @@
+package tristate
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Fold combines the values of seq from left to right, starting from init,
+// for custom aggregations beyond All and Any:
+//
+//	explicit := tristate.Fold(m.Values(), 0, func(n int, v tristate.TriState) int {
+//		if !v.IsNone() {
+//			n++
+//		}
+//		return n
+//	})
+func Fold[T any](seq iter.Seq[TriState], init T, fn func(T, TriState) T) T {
+	acc := init
+	for v := range seq {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// FoldUntil is Fold with early exit: it stops consuming seq as soon as fn
+// reports done, returning the accumulator at that point.
+func FoldUntil[T any](seq iter.Seq[TriState], init T, fn func(T, TriState) (acc T, done bool)) T {
+	acc := init
+	for v := range seq {
+		var done bool
+		if acc, done = fn(acc, v); done {
+			break
+		}
+	}
+	return acc
+}
+
+// Traverse maps every element of seq to a TriState with f and folds op
+// over the results under logic, as ReduceParallel does for a Slice. It
+// stops calling f as soon as the result is decided: under Kleene logic at
+// the first False for OpAnd or True for OpOr, and under Bochvar logic only
+// at the first None, since a later None overrides any other value.
+// Traversing an empty sequence yields True for OpAnd and False for
+// OpOr.
+func Traverse[E any](seq iter.Seq[E], f func(E) TriState, logic Logic, op Op) TriState {
+	var decisive State
+	switch op {
+	case OpAnd:
+		decisive = False
+	case OpOr:
+		decisive = True
+	default:
+		panic(fmt.Sprintf("tristate: unknown Op %d", op))
+	}
+	var trueN, falseN, noneN int
+	for e := range seq {
+		v := f(e)
+		switch {
+		case v.value == None:
+			if logic == Bochvar {
+				return v
+			}
+			noneN++
+		case v.value == decisive && logic != Bochvar:
+			return v
+		case v.value == True:
+			trueN++
+		default:
+			falseN++
+		}
+	}
+	return logic.reduce(op, trueN, falseN, noneN)
+}
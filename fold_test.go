@@ -0,0 +1,73 @@
+package tristate
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	vals := []TriState{New(true), {}, New(false), New(true)}
+	explicit := Fold(slices.Values(vals), 0, func(n int, v TriState) int {
+		if !v.IsNone() {
+			n++
+		}
+		return n
+	})
+	if explicit != 3 {
+		t.Errorf("Fold() counted %d explicit values, want 3", explicit)
+	}
+
+	var seen int
+	firstNone := FoldUntil(slices.Values(vals), -1, func(i int, v TriState) (int, bool) {
+		seen++
+		return i + 1, v.IsNone()
+	})
+	if firstNone != 1 || seen != 2 {
+		t.Errorf("FoldUntil() = %d after %d values, want 1 after 2", firstNone, seen)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	states := map[string]TriState{"t": New(true), "f": New(false), "n": {}}
+	tests := []struct {
+		name      string
+		input     string
+		logic     Logic
+		op        Op
+		want      State
+		wantCalls int
+	}{
+		{"And stops at False", "tnft", Kleene, OpAnd, False, 3},
+		{"And with None", "tnt", Kleene, OpAnd, None, 3},
+		{"And all True", "tt", Kleene, OpAnd, True, 2},
+		{"Or stops at True", "fnt", Kleene, OpOr, True, 3},
+		{"Or with None", "fn", Kleene, OpOr, None, 2},
+		{"Bochvar stops at None", "tnf", Bochvar, OpAnd, None, 2},
+		{"Bochvar And scans past False", "fn", Bochvar, OpAnd, None, 2},
+		{"Bochvar Or scans past True", "tn", Bochvar, OpOr, None, 2},
+		{"Bochvar And without None", "tft", Bochvar, OpAnd, False, 3},
+		{"Empty And", "", Kleene, OpAnd, True, 0},
+		{"Empty Or", "", Kleene, OpOr, False, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			got := Traverse(slices.Values([]byte(tt.input)), func(b byte) TriState {
+				calls++
+				return states[string(b)]
+			}, tt.logic, tt.op)
+			if got.value != tt.want || calls != tt.wantCalls {
+				t.Errorf("Traverse() = %v after %d calls, want %v after %d", got.value, calls, tt.want, tt.wantCalls)
+			}
+
+			// The result matches ReduceParallel over the same values.
+			var s Slice
+			for _, b := range []byte(tt.input) {
+				s.Append(states[string(b)])
+			}
+			if want := ReduceParallel(&s, tt.logic, tt.op, 1); got != want {
+				t.Errorf("Traverse() = %v, ReduceParallel() = %v", got.value, want.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package tristate
+
+import (
+	"html"
+	"html/template"
+	"net/url"
+)
+
+// formSentinelValue is submitted by the hidden input from FormSentinel.
+const formSentinelValue = "false"
+
+// FormSentinel returns a hidden input to render immediately before a
+// checkbox with the same name:
+//
+//	{{ formSentinel "audit" }}
+//	<input type="checkbox" name="audit" {{ if .Audit.IsTrue }}checked{{ end }}>
+//
+// A browser omits unchecked checkboxes from the submission, so the sentinel
+// is what lets DecodeForm tell an unchecked box apart from a form that
+// never rendered the field.
+func FormSentinel(field string) template.HTML {
+	return template.HTML(`<input type="hidden" name="` + html.EscapeString(field) + `" value="` + formSentinelValue + `">`)
+}
+
+// DecodeForm reads a checkbox, radio group, or select from parsed form
+// values. A field missing from values was not on the form and decodes to
+// None. Otherwise the last submitted value wins, so a checked checkbox
+// overrides its sentinel: words in DefaultVocabulary (including the
+// browser default "on") map to their State, and any other value is taken
+// as a checked checkbox with a custom value attribute, meaning True.
+func DecodeForm(values url.Values, field string) TriState {
+	vals, ok := values[field]
+	if !ok || len(vals) == 0 {
+		return TriState{}
+	}
+	v, err := DefaultVocabulary.Parse(vals[len(vals)-1])
+	if err != nil {
+		return New(true)
+	}
+	return v
+}
+
+// UnmarshalParam decodes a single form or query value with
+// DefaultVocabulary, so a checkbox's "on" decodes to True and an empty
+// value to None. It implements the BindUnmarshaler interfaces of both Gin
+// and Echo, letting their binders populate TriState fields tagged
+// `form:"archived"`, `query:"archived"`, or Echo's `param:"archived"`; a
+// parameter missing from the request leaves the field None. Gin binds
+// only the first value of a repeated parameter, so decode FormSentinel
+// checkboxes with DecodeForm instead.
+func (t *TriState) UnmarshalParam(param string) error {
+	v, err := DefaultVocabulary.Parse(param)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
@@ -0,0 +1,43 @@
+package tristate
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDecodeForm(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+		want   State
+	}{
+		{"Not rendered", url.Values{"other": {"on"}}, None},
+		{"Unchecked", url.Values{"audit": {"false"}}, False},
+		{"Checked", url.Values{"audit": {"false", "on"}}, True},
+		{"Checked without sentinel", url.Values{"audit": {"on"}}, True},
+		{"Custom checkbox value", url.Values{"audit": {"false", "audit-enabled"}}, True},
+		{"Select none", url.Values{"audit": {""}}, None},
+		{"Radio false", url.Values{"audit": {"no"}}, False},
+		{"Empty slice", url.Values{"audit": {}}, None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecodeForm(tt.values, "audit"); got.value != tt.want {
+				t.Errorf("DecodeForm() = %v, want %v", got.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormSentinel(t *testing.T) {
+	got := string(FormSentinel(`a"b`))
+	if !strings.Contains(got, `type="hidden"`) || !strings.Contains(got, `name="a&#34;b"`) {
+		t.Errorf("FormSentinel() = %s", got)
+	}
+
+	// The sentinel's value must decode as an unchecked box.
+	if v := DecodeForm(url.Values{"audit": {formSentinelValue}}, "audit"); !v.IsFalse() {
+		t.Errorf("sentinel decodes to %v", v)
+	}
+}
@@ -0,0 +1,39 @@
+package tristate
+
+// FromFuzzBytes draws a TriState from the front of fuzzer-supplied data and
+// returns it with the remaining bytes, so a native fuzz target can build
+// any number of values from its []byte argument:
+//
+//	f.Fuzz(func(t *testing.T, data []byte) {
+//		a, data := tristate.FromFuzzBytes(data)
+//		b, _ := tristate.FromFuzzBytes(data)
+//		...
+//	})
+//
+// Each byte maps to one of the three states; empty data yields None.
+func FromFuzzBytes(data []byte) (TriState, []byte) {
+	if len(data) == 0 {
+		return TriState{}, nil
+	}
+	return TriState{value: State(data[0] % 3)}, data[1:]
+}
+
+// JSONSeeds returns a seed corpus for fuzzing JSON decoding of TriState:
+// the valid encodings plus near misses that must be rejected.
+func JSONSeeds() [][]byte {
+	return [][]byte{
+		[]byte("true"), []byte("false"), []byte("null"),
+		[]byte(`"true"`), []byte("1"), []byte("0"), []byte("TRUE"),
+		[]byte(" true"), []byte("nul"), []byte("{}"), []byte(""),
+	}
+}
+
+// TextSeeds returns a seed corpus for fuzzing the text decoders, Parse
+// and Vocabulary.Parse: the accepted spellings in assorted case and
+// spacing, plus near misses.
+func TextSeeds() []string {
+	return []string{
+		"true", "false", "none", "", "1", "0", "t", "F", " TRUE ", "Unset",
+		"yes", "off", "null", "maybe", "tru", "2", "\x00", "true\n",
+	}
+}
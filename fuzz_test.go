@@ -0,0 +1,46 @@
+package tristate
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFromFuzzBytes(t *testing.T) {
+	a, rest := FromFuzzBytes([]byte{2, 1, 3})
+	b, rest := FromFuzzBytes(rest)
+	c, rest := FromFuzzBytes(rest)
+	d, rest := FromFuzzBytes(rest)
+	if a.value != True || b.value != False || c.value != None || d.value != None || rest != nil {
+		t.Errorf("FromFuzzBytes drew %v, %v, %v, %v, rest %v", a.value, b.value, c.value, d.value, rest)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range TextSeeds() {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := Parse(s)
+		if err != nil {
+			return
+		}
+		if again, err := Parse(v.String()); err != nil || again != v {
+			t.Errorf("Parse(%q) = %v does not round-trip: %v, %v", s, v, again, err)
+		}
+	})
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	for _, b := range JSONSeeds() {
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v TriState
+		if err := v.UnmarshalJSON(data); err != nil {
+			return
+		}
+		if out, _ := v.MarshalJSON(); !bytes.Equal(out, data) {
+			t.Errorf("UnmarshalJSON(%q) re-encodes as %q", data, out)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+// Package fuzzy extends tristate with graded truth: a Value is a
+// confidence in [0,1] that a condition holds, such as the score of an
+// ML-driven eligibility signal. Values combine with AND and OR under a
+// chosen Rule and degrade to a tristate.TriState only at the end, through
+// Thresholds, so probabilistic signals are not snapped to booleans early:
+//
+//	eligible := fuzzy.Product.And(fraudScore.Not(), fuzzy.Of(0.9))
+//	decision := eligible.TriState(fuzzy.DefaultThresholds)
+//
+// The middle band between the thresholds becomes None, leaving the
+// decision to a fallback or a human.
+package fuzzy
+
+import (
+	"fmt"
+	"math"
+
+	"tristate"
+)
+
+// Value is a confidence in [0,1] that a condition holds: 0 is certainly
+// false, 1 certainly true.
+type Value float64
+
+// Of returns p as a Value, clamped to [0,1]. NaN, meaning no information,
+// becomes 0.5.
+func Of(p float64) Value {
+	switch {
+	case math.IsNaN(p):
+		return 0.5
+	case p < 0:
+		return 0
+	case p > 1:
+		return 1
+	}
+	return Value(p)
+}
+
+// FromTriState returns 1 for True, 0 for False, and 0.5 for None.
+func FromTriState(t tristate.TriState) Value {
+	switch {
+	case t.IsTrue():
+		return 1
+	case t.IsFalse():
+		return 0
+	default:
+		return 0.5
+	}
+}
+
+// Not returns the complement 1-v.
+func (v Value) Not() Value { return 1 - v }
+
+// TriState degrades v to a TriState using th.
+func (v Value) TriState(th Thresholds) tristate.TriState {
+	switch {
+	case float64(v) >= th.True:
+		return tristate.New(true)
+	case float64(v) <= th.False:
+		return tristate.New(false)
+	default:
+		return tristate.TriState{}
+	}
+}
+
+// Thresholds degrade a Value to a TriState: at or below False it is
+// False, at or above True it is True, and in between it is None.
+type Thresholds struct {
+	False float64
+	True  float64
+}
+
+// DefaultThresholds treat confidences of 0.8 and above as True and 0.2
+// and below as False.
+var DefaultThresholds = Thresholds{False: 0.2, True: 0.8}
+
+// Rule selects how AND and OR combine Values. On 0 and 1 every rule agrees
+// with boolean logic. Taking None as 0.5, Zadeh also agrees with Kleene
+// logic, while Product and Lukasiewicz compound the uncertainty.
+type Rule uint8
+
+const (
+	// Zadeh uses min for AND and max for OR. It suits signals that are
+	// strongly correlated.
+	Zadeh Rule = iota
+	// Product multiplies for AND and uses a+b-ab for OR, the probability
+	// rules for independent events.
+	Product
+	// Lukasiewicz uses max(0, a+b-1) for AND and min(1, a+b) for OR. It
+	// suits mutually exclusive signals.
+	Lukasiewicz
+)
+
+// And returns the conjunction of a and b under r.
+func (r Rule) And(a, b Value) Value {
+	switch r {
+	case Zadeh:
+		return min(a, b)
+	case Product:
+		return a * b
+	case Lukasiewicz:
+		return max(0, a+b-1)
+	default:
+		panic(fmt.Sprintf("fuzzy: unknown Rule %d", r))
+	}
+}
+
+// Or returns the disjunction of a and b under r.
+func (r Rule) Or(a, b Value) Value {
+	switch r {
+	case Zadeh:
+		return max(a, b)
+	case Product:
+		return a + b - a*b
+	case Lukasiewicz:
+		return min(1, a+b)
+	default:
+		panic(fmt.Sprintf("fuzzy: unknown Rule %d", r))
+	}
+}
+
+// All returns the conjunction of vals under r. All of an empty list is 1.
+func (r Rule) All(vals ...Value) Value {
+	out := Value(1)
+	for _, v := range vals {
+		out = r.And(out, v)
+	}
+	return out
+}
+
+// Any returns the disjunction of vals under r. Any of an empty list is 0.
+func (r Rule) Any(vals ...Value) Value {
+	out := Value(0)
+	for _, v := range vals {
+		out = r.Or(out, v)
+	}
+	return out
+}
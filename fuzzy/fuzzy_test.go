@@ -0,0 +1,96 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+
+	"tristate"
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want Value
+	}{
+		{0.3, 0.3},
+		{-1, 0},
+		{2, 1},
+		{math.NaN(), 0.5},
+	}
+	for _, tt := range tests {
+		if got := Of(tt.in); got != tt.want {
+			t.Errorf("Of(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValue_TriState(t *testing.T) {
+	tests := []struct {
+		v    Value
+		want tristate.TriState
+	}{
+		{0, tristate.New(false)},
+		{0.2, tristate.New(false)},
+		{0.5, tristate.TriState{}},
+		{0.79, tristate.TriState{}},
+		{0.8, tristate.New(true)},
+		{1, tristate.New(true)},
+	}
+	for _, tt := range tests {
+		if got := tt.v.TriState(DefaultThresholds); got != tt.want {
+			t.Errorf("%v.TriState() = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+	for _, v := range []tristate.TriState{tristate.New(true), tristate.New(false), {}} {
+		if got := FromTriState(v).TriState(DefaultThresholds); got != v {
+			t.Errorf("FromTriState(%v) round-trips to %v", v, got)
+		}
+	}
+}
+
+func TestRule(t *testing.T) {
+	tests := []struct {
+		rule    Rule
+		a, b    Value
+		and, or Value
+	}{
+		{Zadeh, 0.3, 0.6, 0.3, 0.6},
+		{Product, 0.5, 0.5, 0.25, 0.75},
+		{Lukasiewicz, 0.3, 0.6, 0, 0.9},
+		{Lukasiewicz, 0.7, 0.6, 0.3, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.And(tt.a, tt.b); math.Abs(float64(got-tt.and)) > 1e-9 {
+			t.Errorf("%d.And(%v, %v) = %v, want %v", tt.rule, tt.a, tt.b, got, tt.and)
+		}
+		if got := tt.rule.Or(tt.a, tt.b); math.Abs(float64(got-tt.or)) > 1e-9 {
+			t.Errorf("%d.Or(%v, %v) = %v, want %v", tt.rule, tt.a, tt.b, got, tt.or)
+		}
+	}
+}
+
+// On crisp inputs every rule agrees with boolean logic.
+func TestRule_Crisp(t *testing.T) {
+	for _, r := range []Rule{Zadeh, Product, Lukasiewicz} {
+		for _, a := range []Value{0, 1} {
+			for _, b := range []Value{0, 1} {
+				if got, want := r.And(a, b), min(a, b); got != want {
+					t.Errorf("rule %d: And(%v, %v) = %v", r, a, b, got)
+				}
+				if got, want := r.Or(a, b), max(a, b); got != want {
+					t.Errorf("rule %d: Or(%v, %v) = %v", r, a, b, got)
+				}
+			}
+		}
+		if r.All() != 1 || r.Any() != 0 {
+			t.Errorf("rule %d: empty All/Any = %v/%v", r, r.All(), r.Any())
+		}
+	}
+	// Zadeh matches Kleene logic with None at 0.5.
+	if got := Zadeh.All(1, 0.5).TriState(DefaultThresholds); !got.IsNone() {
+		t.Errorf("Zadeh True AND None = %v, want None", got)
+	}
+	if got := Zadeh.Any(0, 0.5, 1).TriState(DefaultThresholds); !got.IsTrue() {
+		t.Errorf("Zadeh False OR None OR True = %v, want True", got)
+	}
+}
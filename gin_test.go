@@ -0,0 +1,71 @@
+package tristate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ginFilter struct {
+	Archived TriState `form:"archived" json:"archived"`
+	Pinned   TriState `form:"pinned" json:"pinned"`
+}
+
+func TestTriState_GinBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name         string
+		req          *http.Request
+		archived     State
+		pinned       State
+		wantErr      bool
+		bindWithJSON bool
+	}{
+		{"Query", httptest.NewRequest(http.MethodGet, "/?archived=true&pinned=0", nil), True, False, false, false},
+		{"Missing query", httptest.NewRequest(http.MethodGet, "/?pinned=on", nil), None, True, false, false},
+		{"Empty query", httptest.NewRequest(http.MethodGet, "/?archived=", nil), None, None, false, false},
+		{"Invalid query", httptest.NewRequest(http.MethodGet, "/?archived=maybe", nil), None, None, true, false},
+		{"JSON body", httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"archived":false,"pinned":null}`)), False, None, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = tt.req
+			var f ginFilter
+			var err error
+			if tt.bindWithJSON {
+				c.Request.Header.Set("Content-Type", "application/json")
+				err = c.ShouldBindJSON(&f)
+			} else {
+				err = c.ShouldBindQuery(&f)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("bind error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if f.Archived.value != tt.archived || f.Pinned.value != tt.pinned {
+				t.Errorf("got %+v, want archived=%v pinned=%v", f, tt.archived, tt.pinned)
+			}
+		})
+	}
+}
+
+func TestTriState_GinForm(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("archived=false&archived=on"))
+	c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var f ginFilter
+	if err := c.ShouldBind(&f); err != nil {
+		t.Fatalf("ShouldBind failed: %v", err)
+	}
+	// Gin binds the first value of a repeated field to a scalar, so a
+	// sentinel-plus-checkbox pair needs DecodeForm instead.
+	if f.Archived.value != False || f.Pinned.value != None {
+		t.Errorf("got %+v", f)
+	}
+}
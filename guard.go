@@ -0,0 +1,84 @@
+package tristate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnset is returned by the ForbidUnset guard.
+var ErrUnset = errors.New("tristate: explicit value cannot be unset")
+
+// Guard vetoes a transition from old to new by returning an error. Guards
+// are attached with Atomic.SetGuard and Observable.SetGuard and consulted
+// by TryStore; they are not called when the value would not change.
+type Guard func(old, new TriState) error
+
+// ForbidUnset is a Guard refusing to return an explicit value to None, so
+// clearing a flag takes a deliberate Store rather than a stray TryStore.
+func ForbidUnset(old, new TriState) error {
+	if !old.IsNone() && new.IsNone() {
+		return fmt.Errorf("%w: was %v", ErrUnset, old)
+	}
+	return nil
+}
+
+// SetGuard attaches g to a, replacing any previous guard. A nil g removes
+// it.
+func (a *Atomic) SetGuard(g Guard) {
+	if g == nil {
+		a.guard.Store(nil)
+		return
+	}
+	a.guard.Store(&g)
+}
+
+// TryStore sets the value to v unless the attached guard vetoes the
+// transition, in which case it returns the guard's error and leaves the
+// value unchanged. The guard sees the value being replaced, even when
+// other goroutines store concurrently. Store, Swap, and CompareAndSwap
+// bypass the guard and serve as explicit resets.
+func (a *Atomic) TryStore(v TriState) error {
+	for {
+		old := a.Load()
+		if old == v {
+			return nil
+		}
+		if g := a.guard.Load(); g != nil {
+			if err := (*g)(old, v); err != nil {
+				return err
+			}
+		}
+		if a.CompareAndSwap(old, v) {
+			return nil
+		}
+	}
+}
+
+// SetGuard attaches g to o, replacing any previous guard. A nil g removes
+// it.
+func (o *Observable) SetGuard(g Guard) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.guard = g
+}
+
+// TryStore is Store gated by the attached guard: if the guard vetoes the
+// transition, TryStore returns its error without changing the value or
+// notifying subscribers. Store bypasses the guard and serves as an
+// explicit reset.
+func (o *Observable) TryStore(v TriState) error {
+	o.notify.Lock()
+	defer o.notify.Unlock()
+
+	o.mu.Lock()
+	old, g := o.value, o.guard
+	o.mu.Unlock()
+
+	if old != v && g != nil {
+		if err := g(old, v); err != nil {
+			return err
+		}
+	}
+	o.store(v)
+	return nil
+}
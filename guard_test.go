@@ -0,0 +1,79 @@
+package tristate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestForbidUnset(t *testing.T) {
+	tests := []struct {
+		old, new TriState
+		wantErr  bool
+	}{
+		{TriState{}, New(true), false},
+		{New(true), New(false), false},
+		{New(true), TriState{}, true},
+		{New(false), TriState{}, true},
+	}
+	for _, tt := range tests {
+		if err := ForbidUnset(tt.old, tt.new); (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrUnset)) {
+			t.Errorf("ForbidUnset(%v, %v) = %v, wantErr %v", tt.old, tt.new, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAtomic_TryStore(t *testing.T) {
+	var a Atomic
+	if err := a.TryStore(New(true)); err != nil {
+		t.Fatalf("TryStore without guard: %v", err)
+	}
+	a.SetGuard(ForbidUnset)
+	if err := a.TryStore(TriState{}); !errors.Is(err, ErrUnset) {
+		t.Errorf("TryStore(None) = %v, want ErrUnset", err)
+	}
+	if a.Load().value != True {
+		t.Errorf("vetoed TryStore changed the value to %v", a.Load().value)
+	}
+	if err := a.TryStore(New(false)); err != nil || a.Load().value != False {
+		t.Errorf("TryStore(false) = %v, value %v", err, a.Load().value)
+	}
+	a.Store(TriState{}) // explicit reset bypasses the guard
+	if a.Load().value != None {
+		t.Errorf("Store(None) left %v", a.Load().value)
+	}
+	a.SetGuard(nil)
+	a.Store(New(true))
+	if err := a.TryStore(TriState{}); err != nil {
+		t.Errorf("TryStore after removing guard: %v", err)
+	}
+}
+
+func TestObservable_TryStore(t *testing.T) {
+	o := NewObservable(New(true))
+	var changes []Change
+	o.OnChange(func(c Change) { changes = append(changes, c) })
+	veto := errors.New("audit must stay on")
+	o.SetGuard(func(old, new TriState) error {
+		if old.IsTrue() {
+			return veto
+		}
+		return nil
+	})
+
+	if err := o.TryStore(New(false)); err != veto {
+		t.Errorf("TryStore(false) = %v, want the guard's error", err)
+	}
+	if err := o.TryStore(New(true)); err != nil {
+		t.Errorf("TryStore of the current value = %v, want nil", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("vetoed TryStore notified %+v", changes)
+	}
+	o.Store(TriState{})
+	if err := o.TryStore(New(false)); err != nil || o.Load().value != False {
+		t.Errorf("TryStore(false) = %v, value %v", err, o.Load().value)
+	}
+	if len(changes) != 2 {
+		t.Errorf("got %d changes, want 2", len(changes))
+	}
+}
@@ -0,0 +1,30 @@
+package tristate
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ParseHeader reads a boolean-ish header such as X-Dry-Run. A missing or
+// empty header is None. Values are read as a comma-separated token list
+// across all lines of the header, each token parsed with DefaultVocabulary;
+// the result is the tokens' common value, or None if any token is invalid
+// or two tokens disagree, since a request cannot be trusted to mean either.
+func ParseHeader(h http.Header, name string) TriState {
+	var out TriState
+	for _, line := range h.Values(name) {
+		for tok := range strings.SplitSeq(line, ",") {
+			if tok = strings.TrimSpace(tok); tok == "" {
+				continue
+			}
+			v, err := DefaultVocabulary.Parse(tok)
+			if err != nil || !out.IsNone() && !v.IsNone() && v != out {
+				return TriState{}
+			}
+			if !v.IsNone() {
+				out = v
+			}
+		}
+	}
+	return out
+}
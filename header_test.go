@@ -0,0 +1,37 @@
+package tristate
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   State
+	}{
+		{"Missing", nil, None},
+		{"Empty", []string{""}, None},
+		{"True", []string{"true"}, True},
+		{"Token word", []string{"On"}, True},
+		{"False", []string{"0"}, False},
+		{"List agreeing", []string{"yes, true"}, True},
+		{"Lines agreeing", []string{"false", "no"}, False},
+		{"Empty tokens skipped", []string{" , false,"}, False},
+		{"None token ignored", []string{"none, true"}, True},
+		{"Conflicting", []string{"true", "false"}, None},
+		{"Invalid", []string{"true, maybe"}, None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for _, v := range tt.values {
+				h.Add("X-Dry-Run", v)
+			}
+			if got := ParseHeader(h, "x-dry-run"); got.value != tt.want {
+				t.Errorf("ParseHeader() = %v, want %v", got.value, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler serves the Report of an Aggregator as JSON, for readiness and
+// liveness probes. Healthy responds 200 and Unhealthy 503; a Degraded
+// service responds DegradedStatus, so a probe can choose whether degraded
+// counts as ready:
+//
+//	mux.Handle("/readyz", &health.Handler{Aggregator: &checks})
+//	mux.Handle("/livez", &health.Handler{Aggregator: &checks, DegradedStatus: http.StatusOK})
+type Handler struct {
+	Aggregator *Aggregator
+	// DegradedStatus is the response code for Degraded; zero means 500.
+	DegradedStatus int
+}
+
+// ServeHTTP runs the checks with the request's context and writes the
+// Report.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.Aggregator.Run(r.Context())
+	code := http.StatusOK
+	switch report.Status {
+	case Unhealthy:
+		code = http.StatusServiceUnavailable
+	case Degraded:
+		code = h.DegradedStatus
+		if code == 0 {
+			code = http.StatusInternalServerError
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(report)
+}
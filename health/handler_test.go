@@ -0,0 +1,55 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tristate"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		check    tristate.TriResult
+		degraded int
+		want     int
+		status   string
+	}{
+		{"Healthy", tristate.Known(true), 0, http.StatusOK, "healthy"},
+		{"Unhealthy", tristate.Known(false), 0, http.StatusServiceUnavailable, "unhealthy"},
+		{"Degraded", tristate.Unknown(errors.New("timeout")), 0, http.StatusInternalServerError, "degraded"},
+		{"Degraded configured", tristate.Unknown(nil), http.StatusOK, http.StatusOK, "degraded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a Aggregator
+			a.Add("db", result(tt.check))
+			h := &Handler{Aggregator: &a, DegradedStatus: tt.degraded}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.want {
+				t.Errorf("status code = %d, want %d", rec.Code, tt.want)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			var body struct {
+				Status string `json:"status"`
+				Checks []struct {
+					Name  string `json:"name"`
+					State string `json:"state"`
+				} `json:"checks"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %s: %v", rec.Body, err)
+			}
+			if body.Status != tt.status || len(body.Checks) != 1 || body.Checks[0].Name != "db" {
+				t.Errorf("body = %s", rec.Body)
+			}
+		})
+	}
+}
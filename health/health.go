@@ -0,0 +1,214 @@
+// Package health aggregates tri-state health checks. A check is healthy
+// (True), unhealthy (False), or unknown (None) when it could not tell,
+// such as when its dependency timed out; unknown results keep the error
+// that caused them.
+//
+// An Aggregator runs its checks concurrently and combines them: a failing
+// critical check makes the service Unhealthy, while an unknown check, or a
+// failing optional one, only makes it Degraded:
+//
+//	var a health.Aggregator
+//	a.Add("db", func(ctx context.Context) tristate.TriResult { return tristate.ResultOf(true, db.PingContext(ctx)) })
+//	a.AddOptional("cache", cacheCheck)
+//	report := a.Run(ctx)
+//
+// Handler serves the Report over HTTP for readiness and liveness probes.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"tristate"
+)
+
+// Status is the combined health of a service.
+type Status uint8
+
+const (
+	Healthy Status = iota + 1
+	Degraded
+	Unhealthy
+)
+
+// String returns "healthy", "degraded", or "unhealthy".
+func (s Status) String() string {
+	switch s {
+	case Healthy:
+		return "healthy"
+	case Degraded:
+		return "degraded"
+	case Unhealthy:
+		return "unhealthy"
+	default:
+		return fmt.Sprintf("Status(%d)", uint8(s))
+	}
+}
+
+// MarshalText encodes the status as its String form.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// TriState returns True for Healthy, False for Unhealthy, and None for
+// Degraded.
+func (s Status) TriState() tristate.TriState {
+	switch s {
+	case Healthy:
+		return tristate.New(true)
+	case Unhealthy:
+		return tristate.New(false)
+	default:
+		return tristate.TriState{}
+	}
+}
+
+// Check reports the health of one dependency. It should return promptly
+// once ctx is done.
+type Check func(ctx context.Context) tristate.TriResult
+
+// StateCheck adapts a check returning a bare TriState.
+func StateCheck(fn func(ctx context.Context) tristate.TriState) Check {
+	return func(ctx context.Context) tristate.TriResult {
+		if b, ok := fn(ctx).Bool(); ok {
+			return tristate.Known(b)
+		}
+		return tristate.Unknown(nil)
+	}
+}
+
+// Result is the outcome of one check in a Report.
+type Result struct {
+	Name     string
+	Optional bool
+	Value    tristate.TriState
+	Err      error
+	Duration time.Duration
+}
+
+// State returns "healthy", "unhealthy", or "unknown".
+func (r Result) State() string {
+	switch {
+	case r.Value.IsTrue():
+		return "healthy"
+	case r.Value.IsFalse():
+		return "unhealthy"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON encodes r with its State and the error message, if any.
+func (r Result) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Name     string `json:"name"`
+		State    string `json:"state"`
+		Optional bool   `json:"optional,omitempty"`
+		Error    string `json:"error,omitempty"`
+		Duration string `json:"duration"`
+	}{Name: r.Name, State: r.State(), Optional: r.Optional, Duration: r.Duration.String()}
+	if r.Err != nil {
+		out.Error = r.Err.Error()
+	}
+	return json.Marshal(out)
+}
+
+// Report is the combined Status and the result of each check, in the
+// order the checks were added.
+type Report struct {
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Aggregator runs a set of named checks. The zero value has no checks,
+// which report Healthy. Add checks before calling Run; Run is safe for
+// concurrent use.
+type Aggregator struct {
+	// Timeout bounds each check; zero means only the Run context does.
+	// A check still running at its deadline is reported unknown.
+	Timeout time.Duration
+	// UnknownFails makes an unknown critical check Unhealthy rather than
+	// Degraded.
+	UnknownFails bool
+
+	checks []registered
+}
+
+type registered struct {
+	name     string
+	check    Check
+	optional bool
+}
+
+// Add registers a critical check, whose failure makes the service
+// Unhealthy.
+func (a *Aggregator) Add(name string, c Check) {
+	a.checks = append(a.checks, registered{name: name, check: c})
+}
+
+// AddOptional registers a check whose failure only degrades the service.
+func (a *Aggregator) AddOptional(name string, c Check) {
+	a.checks = append(a.checks, registered{name: name, check: c, optional: true})
+}
+
+// Run runs every check concurrently and combines the results.
+func (a *Aggregator) Run(ctx context.Context) Report {
+	results := make([]Result, len(a.checks))
+	var wg sync.WaitGroup
+	for i, c := range a.checks {
+		wg.Go(func() { results[i] = a.run(ctx, c) })
+	}
+	wg.Wait()
+	return Report{Status: a.combine(results), Checks: results}
+}
+
+func (a *Aggregator) run(ctx context.Context, c registered) Result {
+	if a.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	done := make(chan tristate.TriResult, 1)
+	go func() { done <- c.check(ctx) }()
+	var r tristate.TriResult
+	select {
+	case r = <-done:
+	case <-ctx.Done():
+		r = tristate.Unknown(ctx.Err())
+	}
+	return Result{
+		Name:     c.name,
+		Optional: c.optional,
+		Value:    r.TriState(),
+		Err:      r.Err(),
+		Duration: time.Since(start),
+	}
+}
+
+func (a *Aggregator) combine(results []Result) Status {
+	status := Healthy
+	for _, r := range results {
+		s := Healthy
+		switch {
+		case r.Value.IsTrue():
+		case r.Optional:
+			s = Degraded
+		case r.Value.IsFalse(), a.UnknownFails:
+			s = Unhealthy
+		default:
+			s = Degraded
+		}
+		status = max(status, s)
+	}
+	return status
+}
+
+// Failing returns the checks in the report that are not healthy.
+func (r Report) Failing() []Result {
+	return slices.DeleteFunc(slices.Clone(r.Checks), func(c Result) bool { return c.Value.IsTrue() })
+}
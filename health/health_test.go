@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"tristate"
+)
+
+func result(r tristate.TriResult) Check {
+	return func(context.Context) tristate.TriResult { return r }
+}
+
+func TestAggregator_Run(t *testing.T) {
+	down := errors.New("connection refused")
+	tests := []struct {
+		name         string
+		critical     Check
+		optional     Check
+		unknownFails bool
+		want         Status
+	}{
+		{"All healthy", result(tristate.Known(true)), result(tristate.Known(true)), false, Healthy},
+		{"Critical unhealthy", result(tristate.Known(false)), result(tristate.Known(true)), false, Unhealthy},
+		{"Critical unknown degrades", result(tristate.Unknown(down)), result(tristate.Known(true)), false, Degraded},
+		{"Critical unknown fails", result(tristate.Unknown(down)), result(tristate.Known(true)), true, Unhealthy},
+		{"Optional unhealthy degrades", result(tristate.Known(true)), result(tristate.Known(false)), true, Degraded},
+		{"State check", StateCheck(func(context.Context) tristate.TriState { return tristate.TriState{} }), result(tristate.Known(true)), false, Degraded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Aggregator{UnknownFails: tt.unknownFails}
+			a.Add("db", tt.critical)
+			a.AddOptional("cache", tt.optional)
+			r := a.Run(context.Background())
+			if r.Status != tt.want {
+				t.Errorf("Run().Status = %v, want %v", r.Status, tt.want)
+			}
+			if len(r.Checks) != 2 || r.Checks[0].Name != "db" || r.Checks[1].Name != "cache" || !r.Checks[1].Optional {
+				t.Errorf("Run().Checks = %+v", r.Checks)
+			}
+		})
+	}
+
+	var empty Aggregator
+	if r := empty.Run(context.Background()); r.Status != Healthy || r.Status.TriState() != tristate.New(true) {
+		t.Errorf("empty Run() = %v", r.Status)
+	}
+}
+
+func TestAggregator_Timeout(t *testing.T) {
+	a := Aggregator{Timeout: 10 * time.Millisecond}
+	a.Add("slow", func(context.Context) tristate.TriResult {
+		time.Sleep(time.Second)
+		return tristate.Known(true)
+	})
+	r := a.Run(context.Background())
+	if r.Status != Degraded || !errors.Is(r.Checks[0].Err, context.DeadlineExceeded) {
+		t.Errorf("Run() = %v, %v; want Degraded by a deadline", r.Status, r.Checks[0].Err)
+	}
+	if f := r.Failing(); len(f) != 1 || f[0].Name != "slow" {
+		t.Errorf("Failing() = %+v", f)
+	}
+}
+
+func TestReport_JSON(t *testing.T) {
+	r := Report{Status: Degraded, Checks: []Result{
+		{Name: "db", Value: tristate.New(true), Duration: time.Millisecond},
+		{Name: "cache", Optional: true, Err: errors.New("timeout"), Duration: 2 * time.Second},
+	}}
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"status":"degraded","checks":[{"name":"db","state":"healthy","duration":"1ms"},` +
+		`{"name":"cache","state":"unknown","optional":true,"error":"timeout","duration":"2s"}]}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+}
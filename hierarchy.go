@@ -0,0 +1,88 @@
+package tristate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownLevel is returned when a Hierarchy references a level that
+	// has not been added.
+	ErrUnknownLevel = errors.New("tristate: unknown hierarchy level")
+	// ErrCycle is returned when following parents in a Hierarchy revisits a
+	// level.
+	ErrCycle = errors.New("tristate: hierarchy cycle")
+)
+
+// Hierarchy resolves settings across nested levels such as organization,
+// team, and user. Each level holds a Map and names its parent; a setting
+// that is None at one level is inherited from the nearest ancestor that
+// sets it.
+type Hierarchy struct {
+	levels map[string]hierarchyLevel
+}
+
+type hierarchyLevel struct {
+	parent   string
+	settings Map
+}
+
+// NewHierarchy returns an empty Hierarchy.
+func NewHierarchy() *Hierarchy {
+	return &Hierarchy{levels: make(map[string]hierarchyLevel)}
+}
+
+// Add registers (or replaces) the level name with the given settings. An
+// empty parent makes it a root. Parents may be added after their children;
+// references are checked at resolution time.
+func (h *Hierarchy) Add(name, parent string, settings Map) {
+	h.levels[name] = hierarchyLevel{parent: parent, settings: settings}
+}
+
+// Resolve returns the value of key at level name and the name of the level
+// that supplied it, walking up through parents until a set value is found.
+// If no level in the chain sets key it returns None and an empty name.
+func (h *Hierarchy) Resolve(name, key string) (TriState, string, error) {
+	chain, err := h.chain(name)
+	if err != nil {
+		return TriState{}, "", err
+	}
+	for _, lvl := range chain {
+		if v := h.levels[lvl].settings[key]; !v.IsNone() {
+			return v, lvl, nil
+		}
+	}
+	return TriState{}, "", nil
+}
+
+// ResolveAll returns the effective value of every key set anywhere in the
+// chain from name up to its root.
+func (h *Hierarchy) ResolveAll(name string) (Map, error) {
+	chain, err := h.chain(name)
+	if err != nil {
+		return nil, err
+	}
+	out := Map{}
+	for _, lvl := range chain {
+		out = out.MergeMonotone(h.levels[lvl].settings)
+	}
+	return out, nil
+}
+
+// chain returns the level names from name up to its root.
+func (h *Hierarchy) chain(name string) ([]string, error) {
+	var chain []string
+	seen := make(map[string]bool)
+	for lvl := name; lvl != ""; lvl = h.levels[lvl].parent {
+		if seen[lvl] {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrCycle, strings.Join(chain, " -> "), lvl)
+		}
+		if _, ok := h.levels[lvl]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownLevel, lvl)
+		}
+		seen[lvl] = true
+		chain = append(chain, lvl)
+	}
+	return chain, nil
+}
@@ -0,0 +1,65 @@
+package tristate
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestHierarchy_Resolve(t *testing.T) {
+	h := NewHierarchy()
+	h.Add("alice", "payments", Map{"dark_mode": New(true)})
+	h.Add("payments", "acme", Map{"beta": New(true), "dark_mode": {}})
+	h.Add("acme", "", Map{"beta": New(false), "audit": New(true)})
+
+	tests := []struct {
+		level, key string
+		want       State
+		wantFrom   string
+	}{
+		{"alice", "dark_mode", True, "alice"},
+		{"alice", "beta", True, "payments"},
+		{"alice", "audit", True, "acme"},
+		{"payments", "beta", True, "payments"},
+		{"acme", "beta", False, "acme"},
+		{"alice", "missing", None, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level+"/"+tt.key, func(t *testing.T) {
+			got, from, err := h.Resolve(tt.level, tt.key)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if got.value != tt.want || from != tt.wantFrom {
+				t.Errorf("Resolve = (%v, %q), want (%v, %q)", got.value, from, tt.want, tt.wantFrom)
+			}
+		})
+	}
+
+	all, err := h.ResolveAll("alice")
+	if err != nil {
+		t.Fatalf("ResolveAll failed: %v", err)
+	}
+	want := Map{"dark_mode": New(true), "beta": New(true), "audit": New(true)}
+	if !maps.Equal(all, want) {
+		t.Errorf("ResolveAll = %v, want %v", all, want)
+	}
+}
+
+func TestHierarchy_Errors(t *testing.T) {
+	h := NewHierarchy()
+	h.Add("a", "b", nil)
+	h.Add("b", "c", nil)
+	h.Add("c", "a", nil)
+	h.Add("orphan", "ghost", nil)
+
+	if _, _, err := h.Resolve("a", "x"); !errors.Is(err, ErrCycle) {
+		t.Errorf("Resolve on cycle error = %v, want ErrCycle", err)
+	}
+	if _, err := h.ResolveAll("orphan"); !errors.Is(err, ErrUnknownLevel) {
+		t.Errorf("ResolveAll with missing parent error = %v, want ErrUnknownLevel", err)
+	}
+	if _, _, err := h.Resolve("nobody", "x"); !errors.Is(err, ErrUnknownLevel) {
+		t.Errorf("Resolve on unknown level error = %v, want ErrUnknownLevel", err)
+	}
+}
@@ -0,0 +1,204 @@
+package tristate
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// PatchOp is a single RFC 6902 JSON Patch operation on a TriState value.
+// "remove" is the tri-state reading of None: removing a value resets it to
+// None rather than deleting a struct field.
+type PatchOp struct {
+	Op    string   `json:"op"`
+	Path  string   `json:"path"`
+	Value TriState `json:"value,omitzero"`
+}
+
+// GeneratePatch returns the JSON Patch turning the TriState fields of before
+// into those of after: "add" for a None value that became set, "replace" for
+// a changed value, and "remove" for a value that became None. Fields are
+// addressed by JSON Pointer using their JSON names; nested structs, struct
+// pointers, and Map entries are included. Operations are ordered by path.
+//
+// before and after must be structs of the same type or pointers to them.
+func GeneratePatch(before, after any) ([]PatchOp, error) {
+	bv, av := reflect.Indirect(reflect.ValueOf(before)), reflect.Indirect(reflect.ValueOf(after))
+	if !bv.IsValid() || !av.IsValid() || bv.Kind() != reflect.Struct || bv.Type() != av.Type() {
+		return nil, fmt.Errorf("tristate: GeneratePatch requires two structs of the same type, got %T and %T", before, after)
+	}
+	var ops []PatchOp
+	generatePatch(&ops, "", addressable(bv), addressable(av))
+	slices.SortFunc(ops, func(x, y PatchOp) int { return strings.Compare(x.Path, y.Path) })
+	return ops, nil
+}
+
+func generatePatch(ops *[]PatchOp, path string, before, after reflect.Value) {
+	switch before.Type() {
+	case triStateType:
+		if op, ok := patchOp(path, before.Interface().(TriState), after.Interface().(TriState)); ok {
+			*ops = append(*ops, op)
+		}
+		return
+	case mapType:
+		bm, am := before.Interface().(Map), after.Interface().(Map)
+		for k := range bm.MergeOverride(am) {
+			if op, ok := patchOp(path+"/"+escapePointer(k), bm[k], am[k]); ok {
+				*ops = append(*ops, op)
+			}
+		}
+		return
+	}
+
+	switch before.Kind() {
+	case reflect.Pointer:
+		if before.Type().Elem().Kind() != reflect.Struct || (before.IsNil() && after.IsNil()) {
+			return
+		}
+		zero := reflect.New(before.Type().Elem())
+		if before.IsNil() {
+			before = zero
+		}
+		if after.IsNil() {
+			after = zero
+		}
+		generatePatch(ops, path, before.Elem(), after.Elem())
+	case reflect.Struct:
+		if !isPlainStruct(before.Type()) {
+			return
+		}
+		bf, af := jsonFields(before), jsonFields(after)
+		for name, f := range bf {
+			generatePatch(ops, path+"/"+escapePointer(name), f, af[name])
+		}
+	}
+}
+
+// addressable returns an addressable copy of v, so that jsonFields sees
+// its fields as settable.
+func addressable(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	out.Set(v)
+	return out
+}
+
+func patchOp(path string, before, after TriState) (PatchOp, bool) {
+	switch {
+	case before == after:
+		return PatchOp{}, false
+	case after.IsNone():
+		return PatchOp{Op: "remove", Path: path}, true
+	case before.IsNone():
+		return PatchOp{Op: "add", Path: path, Value: after}, true
+	default:
+		return PatchOp{Op: "replace", Path: path, Value: after}, true
+	}
+}
+
+// ApplyPatch applies JSON Patch operations to the TriState fields of the
+// struct pointed to by target. "add" and "replace" set the addressed value,
+// "remove" resets it to None (deleting Map entries), and "test" fails unless
+// the value equals op.Value. Nil struct pointers along a path are allocated
+// by "add" and "replace"; "remove" and "test" treat values below them as
+// None. Operations apply in order and stop at the first error.
+func ApplyPatch(target any, ops []PatchOp) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tristate: ApplyPatch requires a non-nil struct pointer, got %T", target)
+	}
+	for i, op := range ops {
+		if err := applyPatchOp(rv.Elem(), op); err != nil {
+			return fmt.Errorf("tristate: patch op %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return nil
+}
+
+func applyPatchOp(root reflect.Value, op PatchOp) error {
+	if !strings.HasPrefix(op.Path, "/") {
+		return fmt.Errorf("invalid JSON Pointer %q", op.Path)
+	}
+	switch op.Op {
+	case "add", "replace", "remove", "test":
+	default:
+		return fmt.Errorf("unsupported op %q", op.Op)
+	}
+	tokens := strings.Split(op.Path[1:], "/")
+	v := root
+	for i, tok := range tokens {
+		tok = unescapePointer(tok)
+		if v.Kind() == reflect.Pointer {
+			switch {
+			case !v.IsNil():
+				v = v.Elem()
+			case op.Op == "remove" || op.Op == "test":
+				// Nothing is set below a nil pointer. Resolve the rest of
+				// the path against a detached zero value, so bad paths are
+				// still reported and target is left as it was.
+				v = reflect.New(v.Type().Elem()).Elem()
+			default:
+				v.Set(reflect.New(v.Type().Elem()))
+				v = v.Elem()
+			}
+		}
+		if v.Type() == mapType && i == len(tokens)-1 {
+			m := v.Interface().(Map)
+			switch op.Op {
+			case "add", "replace":
+				if m == nil {
+					m = Map{}
+					v.Set(reflect.ValueOf(m))
+				}
+				if op.Value.IsNone() {
+					delete(m, tok)
+				} else {
+					m[tok] = op.Value
+				}
+				return nil
+			case "remove":
+				delete(m, tok)
+				return nil
+			default:
+				return checkTest(op, m[tok])
+			}
+		}
+		if v.Kind() != reflect.Struct || !isPlainStruct(v.Type()) {
+			return fmt.Errorf("path does not address a TriState value")
+		}
+		f, ok := jsonFields(v)[tok]
+		if !ok {
+			return fmt.Errorf("no field %q", tok)
+		}
+		v = f
+	}
+
+	if v.Type() != triStateType {
+		return fmt.Errorf("path does not address a TriState value")
+	}
+	switch op.Op {
+	case "add", "replace":
+		v.Set(reflect.ValueOf(op.Value))
+	case "remove":
+		v.SetZero()
+	default:
+		return checkTest(op, v.Interface().(TriState))
+	}
+	return nil
+}
+
+func checkTest(op PatchOp, got TriState) error {
+	if got != op.Value {
+		return fmt.Errorf("test failed: value is %v", got.value)
+	}
+	return nil
+}
+
+// escapePointer escapes a JSON Pointer reference token per RFC 6901.
+func escapePointer(tok string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(tok)
+}
+
+func unescapePointer(tok string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+}
@@ -0,0 +1,129 @@
+package tristate
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+)
+
+type jsonPatchConfig struct {
+	Audit  TriState     `json:"audit"`
+	Beta   TriState     `json:"beta"`
+	Name   string       `json:"name"`
+	Flags  Map          `json:"flags"`
+	Limits patchLimits  `json:"limits"`
+	Backup *patchLimits `json:"backup"`
+}
+
+func TestGeneratePatch(t *testing.T) {
+	before := jsonPatchConfig{
+		Audit:  New(true),
+		Flags:  Map{"a/b": New(true), "keep": New(false)},
+		Limits: patchLimits{Burst: New(false)},
+	}
+	after := jsonPatchConfig{
+		Beta:   New(true),
+		Name:   "ignored",
+		Flags:  Map{"keep": New(false), "new": New(true)},
+		Limits: patchLimits{Burst: New(true)},
+		Backup: &patchLimits{Burst: New(false)},
+	}
+
+	got, err := GeneratePatch(before, &after)
+	if err != nil {
+		t.Fatalf("GeneratePatch failed: %v", err)
+	}
+	want := []PatchOp{
+		{Op: "remove", Path: "/audit"},
+		{Op: "add", Path: "/backup/burst", Value: New(false)},
+		{Op: "add", Path: "/beta", Value: New(true)},
+		{Op: "remove", Path: "/flags/a~1b"},
+		{Op: "add", Path: "/flags/new", Value: New(true)},
+		{Op: "replace", Path: "/limits/burst", Value: New(true)},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("GeneratePatch = %+v, want %+v", got, want)
+	}
+
+	data, _ := json.Marshal(got[:2])
+	if wantJSON := `[{"op":"remove","path":"/audit"},{"op":"add","path":"/backup/burst","value":false}]`; string(data) != wantJSON {
+		t.Errorf("Marshal = %s, want %s", data, wantJSON)
+	}
+
+	applied := before
+	applied.Flags = maps.Clone(before.Flags)
+	if err := ApplyPatch(&applied, got); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	applied.Name = after.Name
+	if applied.Audit != after.Audit || applied.Beta != after.Beta || applied.Limits != after.Limits ||
+		*applied.Backup != *after.Backup || !maps.Equal(applied.Flags, after.Flags) {
+		t.Errorf("ApplyPatch result = %+v, want %+v", applied, after)
+	}
+}
+
+func TestGeneratePatch_Errors(t *testing.T) {
+	var cfg jsonPatchConfig
+	tests := []struct {
+		name          string
+		before, after any
+	}{
+		{"Nil after", cfg, nil},
+		{"Nil before", nil, cfg},
+		{"Typed nil", &cfg, (*jsonPatchConfig)(nil)},
+		{"Different types", cfg, patchLimits{}},
+		{"Non-struct", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GeneratePatch(tt.before, tt.after); err == nil {
+				t.Error("GeneratePatch succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	var cfg jsonPatchConfig
+	ops := []PatchOp{
+		{Op: "add", Path: "/audit", Value: New(true)},
+		{Op: "test", Path: "/audit", Value: New(true)},
+		{Op: "replace", Path: "/flags/x", Value: New(false)},
+		{Op: "remove", Path: "/backup/burst"},
+	}
+	if err := ApplyPatch(&cfg, ops); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if !cfg.Audit.IsTrue() || !cfg.Flags["x"].IsFalse() || cfg.Backup != nil {
+		t.Errorf("ApplyPatch result = %+v", cfg)
+	}
+
+	tests := []struct {
+		name string
+		op   PatchOp
+	}{
+		{"Failed test", PatchOp{Op: "test", Path: "/audit", Value: New(false)}},
+		{"Unknown field", PatchOp{Op: "add", Path: "/nope", Value: New(true)}},
+		{"Non-tristate field", PatchOp{Op: "add", Path: "/name", Value: New(true)}},
+		{"Unsupported op", PatchOp{Op: "move", Path: "/audit"}},
+		{"Unsupported op under nil", PatchOp{Op: "copy", Path: "/backup/burst"}},
+		{"Test unknown field under nil", PatchOp{Op: "test", Path: "/backup/brust"}},
+		{"Remove unknown field under nil", PatchOp{Op: "remove", Path: "/backup/brust"}},
+		{"Relative path", PatchOp{Op: "add", Path: "audit", Value: New(true)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ApplyPatch(&cfg, []PatchOp{tt.op}); err == nil {
+				t.Error("ApplyPatch succeeded, want error")
+			}
+			if cfg.Backup != nil {
+				t.Errorf("ApplyPatch allocated Backup = %+v", cfg.Backup)
+			}
+		})
+	}
+
+	if err := ApplyPatch(&cfg, []PatchOp{{Op: "test", Path: "/backup/burst"}}); err != nil {
+		t.Errorf("test None under nil pointer failed: %v", err)
+	}
+}
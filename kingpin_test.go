@@ -0,0 +1,32 @@
+package tristate
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+func TestTriState_Kingpin(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want State
+	}{
+		{"Omitted", nil, None},
+		{"Bare", []string{"--audit"}, True},
+		{"Negated", []string{"--no-audit"}, False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var audit TriState
+			app := kingpin.New("app", "")
+			app.Flag("audit", "Enable auditing.").SetValue(&audit)
+			if _, err := app.Parse(tt.args); err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if audit.value != tt.want {
+				t.Errorf("audit = %v, want %v", audit.value, tt.want)
+			}
+		})
+	}
+}
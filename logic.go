@@ -0,0 +1,96 @@
+package tristate
+
+import "fmt"
+
+// Logic selects the three-valued logic used to combine None with explicit
+// values.
+type Logic uint8
+
+const (
+	// Kleene is strong three-valued logic: a decisive operand wins over
+	// None, so False AND None is False and True OR None is True.
+	Kleene Logic = iota
+	// Bochvar is weak three-valued logic: None is infectious, so any None
+	// operand makes the result None.
+	Bochvar
+)
+
+// Op is a binary logical connective used by reductions.
+type Op uint8
+
+const (
+	OpAnd Op = iota
+	OpOr
+)
+
+// reduce returns the result of folding op under logic over a collection
+// with the given tallies.
+func (l Logic) reduce(op Op, trueN, falseN, noneN int) TriState {
+	if l == Bochvar && noneN > 0 {
+		return TriState{}
+	}
+	switch op {
+	case OpAnd:
+		return conjunction(falseN, noneN)
+	case OpOr:
+		return disjunction(trueN, noneN)
+	default:
+		panic(fmt.Sprintf("tristate: unknown Op %d", op))
+	}
+}
+
+// --- Bulk Logic ---
+//
+// Each packed slot holds None as 00, False as 01, and True as 10, so the
+// low bits of a word form a "false" bitmap and the high bits a "true"
+// bitmap. Kleene logic then reduces to plain bitwise operations on those
+// bitmaps, 32 elements per word.
+
+// SliceAnd returns the elementwise Kleene AND of a and b. It panics if the
+// Slices differ in length.
+func SliceAnd(a, b *Slice) Slice {
+	return zipWords(a, b, func(tA, fA, tB, fB uint64) (uint64, uint64) {
+		return tA & tB, fA | fB
+	})
+}
+
+// SliceOr returns the elementwise Kleene OR of a and b. It panics if the
+// Slices differ in length.
+func SliceOr(a, b *Slice) Slice {
+	return zipWords(a, b, func(tA, fA, tB, fB uint64) (uint64, uint64) {
+		return tA | tB, fA & fB
+	})
+}
+
+// SliceNot returns the elementwise negation of s. None stays None.
+func SliceNot(s *Slice) Slice {
+	out := Slice{words: make([]uint64, len(s.words)), n: s.n}
+	for i, w := range s.words {
+		t, f := splitWord(w)
+		out.words[i] = joinWord(f, t)
+	}
+	return out
+}
+
+func zipWords(a, b *Slice, op func(tA, fA, tB, fB uint64) (t, f uint64)) Slice {
+	if a.n != b.n {
+		panic("tristate: Slice length mismatch")
+	}
+	out := Slice{words: make([]uint64, wordsFor(a.n)), n: a.n}
+	for i := range out.words {
+		tA, fA := splitWord(a.words[i])
+		tB, fB := splitWord(b.words[i])
+		out.words[i] = joinWord(op(tA, fA, tB, fB))
+	}
+	return out
+}
+
+// splitWord returns the true and false bitmaps of w, aligned to the low bit
+// of each slot.
+func splitWord(w uint64) (t, f uint64) {
+	return w >> 1 & lowBits, w & lowBits
+}
+
+func joinWord(t, f uint64) uint64 {
+	return t<<1 | f
+}
@@ -0,0 +1,65 @@
+package tristate
+
+import "testing"
+
+// kleene truth tables indexed by State, used as the reference implementation.
+var (
+	kleeneAnd = [3][3]State{
+		None:  {None: None, False: False, True: None},
+		False: {None: False, False: False, True: False},
+		True:  {None: None, False: False, True: True},
+	}
+	kleeneOr = [3][3]State{
+		None:  {None: None, False: None, True: True},
+		False: {None: None, False: False, True: True},
+		True:  {None: True, False: True, True: True},
+	}
+	kleeneNot = [3]State{None: None, False: True, True: False}
+)
+
+func TestSliceLogic(t *testing.T) {
+	// 9 combinations repeated past a word boundary.
+	var a, b Slice
+	for i := 0; i < 100; i++ {
+		a.Append(TriState{value: State(i % 3)})
+		b.Append(TriState{value: State(i / 3 % 3)})
+	}
+
+	and, or, not := SliceAnd(&a, &b), SliceOr(&a, &b), SliceNot(&a)
+	for i := 0; i < a.Len(); i++ {
+		x, y := a.Get(i).value, b.Get(i).value
+		if got := and.Get(i).value; got != kleeneAnd[x][y] {
+			t.Errorf("And(%v, %v) = %v, want %v", x, y, got, kleeneAnd[x][y])
+		}
+		if got := or.Get(i).value; got != kleeneOr[x][y] {
+			t.Errorf("Or(%v, %v) = %v, want %v", x, y, got, kleeneOr[x][y])
+		}
+		if got := not.Get(i).value; got != kleeneNot[x] {
+			t.Errorf("Not(%v) = %v, want %v", x, got, kleeneNot[x])
+		}
+	}
+	if and.Len() != a.Len() || or.Len() != a.Len() || not.Len() != a.Len() {
+		t.Error("result length differs from input length")
+	}
+}
+
+func TestSliceLogic_LengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	a, b := NewSlice(3), NewSlice(4)
+	SliceAnd(&a, &b)
+}
+
+func BenchmarkSliceAnd(b *testing.B) {
+	x, y := NewSlice(1<<20), NewSlice(1<<20)
+	for i := 0; i < x.Len(); i++ {
+		x.Set(i, TriState{value: State(i % 3)})
+		y.Set(i, TriState{value: State(i % 5 % 3)})
+	}
+	for b.Loop() {
+		SliceAnd(&x, &y)
+	}
+}
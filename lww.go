@@ -0,0 +1,44 @@
+package tristate
+
+import (
+	"cmp"
+	"strings"
+	"time"
+)
+
+// LWW is a last-writer-wins register holding a TriState, for replicated
+// services that must converge on a flag value without coordination. Each
+// write is stamped with a time and the ID of the node making it; Merge
+// keeps the latest write, so replicas that exchange state in any order
+// end up equal. Writing None is a real write that clears the value.
+//
+// The zero LWW holds None at the zero time and loses to every write.
+type LWW struct {
+	Value TriState  `json:"value"`
+	Time  time.Time `json:"time,omitzero"`
+	Node  string    `json:"node,omitempty"`
+}
+
+// Set returns l updated with a write of v made by node at time at. A write
+// older than the one l holds is discarded, exactly as if merged.
+func (l LWW) Set(v TriState, at time.Time, node string) LWW {
+	return l.Merge(LWW{Value: v, Time: at, Node: node})
+}
+
+// Merge returns whichever of l and other was written last. Equal times are
+// broken by the greater Node ID, and a remaining tie by the greater State,
+// so Merge is commutative, associative, and idempotent.
+func (l LWW) Merge(other LWW) LWW {
+	if l.compare(other) >= 0 {
+		return l
+	}
+	return other
+}
+
+func (l LWW) compare(other LWW) int {
+	return cmp.Or(
+		l.Time.Compare(other.Time),
+		strings.Compare(l.Node, other.Node),
+		cmp.Compare(l.Value.value, other.Value.value),
+	)
+}
@@ -0,0 +1,81 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLWW_Merge(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := LWW{Value: New(true), Time: t0, Node: "a"}
+	b := LWW{Value: New(false), Time: t0.Add(time.Second), Node: "a"}
+	c := LWW{Value: TriState{}, Time: t0.Add(time.Second), Node: "b"}
+	d := LWW{Value: New(true), Time: t0.Add(time.Second), Node: "b"}
+
+	tests := []struct {
+		name string
+		x, y LWW
+		want LWW
+	}{
+		{"Later wins", a, b, b},
+		{"Zero loses", LWW{}, a, a},
+		{"Node breaks ties", b, c, c},
+		{"Value breaks full ties", c, d, d},
+		{"Idempotent", a, a, a},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.x.Merge(tt.y); got != tt.want {
+				t.Errorf("x.Merge(y) = %+v, want %+v", got, tt.want)
+			}
+			if got := tt.y.Merge(tt.x); got != tt.want {
+				t.Errorf("y.Merge(x) = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// Replicas applying the same writes in different orders converge.
+	writes := []LWW{a, b, c, d}
+	var fwd, rev LWW
+	for i := range writes {
+		fwd = fwd.Merge(writes[i])
+		rev = rev.Merge(writes[len(writes)-1-i])
+	}
+	if fwd != rev || fwd != d {
+		t.Errorf("replicas diverged: %+v vs %+v", fwd, rev)
+	}
+}
+
+func TestLWW_Set(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := LWW{}.Set(New(true), t0.Add(time.Minute), "a")
+	if stale := l.Set(New(false), t0, "b"); stale != l {
+		t.Errorf("stale Set applied: %+v", stale)
+	}
+	if cleared := l.Set(TriState{}, t0.Add(2*time.Minute), "b"); !cleared.Value.IsNone() || cleared.Node != "b" {
+		t.Errorf("clearing Set = %+v", cleared)
+	}
+}
+
+func TestLWW_JSON(t *testing.T) {
+	l := LWW{Value: New(false), Time: time.Date(2026, 1, 1, 0, 0, 0, 5, time.UTC), Node: "eu-1"}
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `{"value":false,"time":"2026-01-01T00:00:00.000000005Z","node":"eu-1"}`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+	var back LWW
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if back.compare(l) != 0 {
+		t.Errorf("round trip = %+v, want %+v", back, l)
+	}
+
+	if data, _ := json.Marshal(LWW{}); string(data) != `{"value":null}` {
+		t.Errorf("zero Marshal() = %s", data)
+	}
+}
@@ -0,0 +1,117 @@
+package tristate
+
+import (
+	"encoding/json"
+	"iter"
+	"maps"
+)
+
+// Map is a set of named TriState values, such as a flag-override set.
+// A missing key and a key mapped to None are equivalent for every operation
+// below; the distinction only survives JSON unmarshaling, where an explicit
+// null is recorded as a None entry.
+type Map map[string]TriState
+
+// --- Merging ---
+
+// MergeOverride returns a new Map holding m with every explicitly set value
+// in src layered on top. None values in src never override m.
+func (m Map) MergeOverride(src Map) Map {
+	out := m.clone(len(src))
+	for k, v := range src {
+		if !v.IsNone() {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// MergeMonotone returns a new Map where keys that are None or missing in m
+// take their value from src. Values already set in m never change, so
+// repeated merges can only add information, never revise it.
+func (m Map) MergeMonotone(src Map) Map {
+	out := m.clone(len(src))
+	for k, v := range src {
+		if out[k].IsNone() && !v.IsNone() {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// Diff returns the entries of other whose value differs from m. Keys that
+// are set in m but None or missing in other are reported as None.
+func (m Map) Diff(other Map) Map {
+	out := Map{}
+	for k, v := range other {
+		if m[k] != v {
+			out[k] = v
+		}
+	}
+	for k, v := range m {
+		if _, ok := other[k]; !ok && !v.IsNone() {
+			out[k] = TriState{}
+		}
+	}
+	return out
+}
+
+func (m Map) clone(extra int) Map {
+	out := make(Map, len(m)+extra)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// Counts returns how many entries are True, False, and None.
+func (m Map) Counts() (trueN, falseN, noneN int) {
+	return countSeq(maps.Values(m))
+}
+
+// --- Iteration ---
+
+// All returns an iterator over the key-value pairs of the Map, in
+// unspecified order.
+func (m Map) All() iter.Seq2[string, TriState] {
+	return maps.All(m)
+}
+
+// Values returns an iterator over the values of the Map, in unspecified
+// order.
+func (m Map) Values() iter.Seq[TriState] {
+	return maps.Values(m)
+}
+
+// CollectMap gathers the key-value pairs of seq into a new Map. Later
+// pairs overwrite earlier ones with the same key.
+func CollectMap(seq iter.Seq2[string, TriState]) Map {
+	return Map(maps.Collect(seq))
+}
+
+// --- JSON Marshaling ---
+
+// MarshalJSON encodes the Map as a JSON object, omitting None entries.
+func (m Map) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	set := make(map[string]TriState, len(m))
+	for k, v := range m {
+		if !v.IsNone() {
+			set[k] = v
+		}
+	}
+	return json.Marshal(set)
+}
+
+// UnmarshalJSON decodes a JSON object of true/false/null values. Keys with
+// an explicit null are kept as None entries.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	var raw map[string]TriState
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = raw
+	return nil
+}
@@ -0,0 +1,103 @@
+package tristate
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+)
+
+func TestMap_MergeOverride(t *testing.T) {
+	base := Map{"a": New(true), "b": New(false), "c": New(true)}
+	src := Map{"a": New(false), "b": {}, "d": New(true)}
+
+	got := base.MergeOverride(src)
+	want := Map{"a": New(false), "b": New(false), "c": New(true), "d": New(true)}
+	if !maps.Equal(got, want) {
+		t.Errorf("MergeOverride() = %v, want %v", got, want)
+	}
+	if !base["a"].IsTrue() {
+		t.Error("MergeOverride modified the receiver")
+	}
+}
+
+func TestMap_MergeMonotone(t *testing.T) {
+	base := Map{"a": New(true), "b": {}}
+	src := Map{"a": New(false), "b": New(false), "c": New(true), "d": {}}
+
+	got := base.MergeMonotone(src)
+	want := Map{"a": New(true), "b": New(false), "c": New(true)}
+	if !maps.Equal(got, want) {
+		t.Errorf("MergeMonotone() = %v, want %v", got, want)
+	}
+}
+
+func TestMap_MergeNilReceiver(t *testing.T) {
+	var m Map
+	got := m.MergeOverride(Map{"a": New(true)})
+	if !got["a"].IsTrue() {
+		t.Error("MergeOverride on nil Map dropped src value")
+	}
+}
+
+func TestMap_Diff(t *testing.T) {
+	before := Map{"same": New(true), "flip": New(true), "cleared": New(false), "removed": New(true), "none": {}}
+	after := Map{"same": New(true), "flip": New(false), "cleared": {}, "added": New(true)}
+
+	got := before.Diff(after)
+	want := Map{"flip": New(false), "cleared": {}, "removed": {}, "added": New(true)}
+	if !maps.Equal(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+}
+
+func TestMap_JSON(t *testing.T) {
+	m := Map{"on": New(true), "off": New(false), "inherit": {}}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `{"off":false,"on":true}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var got Map
+	if err := json.Unmarshal([]byte(`{"on":true,"off":false,"inherit":null}`), &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !maps.Equal(got, m) {
+		t.Errorf("Unmarshal = %v, want %v", got, m)
+	}
+	if _, ok := got["inherit"]; !ok {
+		t.Error("Unmarshal dropped explicit null entry")
+	}
+
+	if err := json.Unmarshal([]byte(`{"on":"yes"}`), &got); err == nil {
+		t.Error("Unmarshal accepted a string value")
+	}
+}
+
+func TestMap_Iterators(t *testing.T) {
+	m := Map{"a": New(true), "b": New(false), "c": {}}
+
+	got := CollectMap(m.All())
+	if !maps.Equal(got, m) {
+		t.Errorf("CollectMap(All()) = %v, want %v", got, m)
+	}
+
+	var set int
+	for v := range m.Values() {
+		if !v.IsNone() {
+			set++
+		}
+	}
+	if set != 2 {
+		t.Errorf("Values() yielded %d set values, want 2", set)
+	}
+}
+
+func TestMap_Counts(t *testing.T) {
+	m := Map{"a": New(true), "b": New(false), "c": {}, "d": New(true)}
+	if gotT, gotF, gotN := m.Counts(); gotT != 2 || gotF != 1 || gotN != 1 {
+		t.Errorf("Counts() = (%d, %d, %d), want (2, 1, 1)", gotT, gotF, gotN)
+	}
+}
@@ -0,0 +1,189 @@
+package tristate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Matrix is a fixed-size rows × columns grid of TriState values, bit-packed
+// in row-major order. It suits permission grids such as role × resource,
+// where None cells mean "inherit".
+type Matrix struct {
+	cells      Slice
+	rows, cols int
+}
+
+// --- Factory Methods ---
+
+// NewMatrix returns a rows × cols Matrix with every cell set to None.
+func NewMatrix(rows, cols int) Matrix {
+	if rows < 0 || cols < 0 {
+		panic("tristate: negative Matrix dimension")
+	}
+	return Matrix{cells: NewSlice(rows * cols), rows: rows, cols: cols}
+}
+
+// --- Accessors ---
+
+// Rows returns the number of rows in the Matrix.
+func (m *Matrix) Rows() int { return m.rows }
+
+// Cols returns the number of columns in the Matrix.
+func (m *Matrix) Cols() int { return m.cols }
+
+// Get returns the cell at row r, column c. It panics if either is out of range.
+func (m *Matrix) Get(r, c int) TriState {
+	return m.cells.Get(m.index(r, c))
+}
+
+// Set stores v at row r, column c. It panics if either is out of range.
+func (m *Matrix) Set(r, c int, v TriState) {
+	m.cells.Set(m.index(r, c), v)
+}
+
+// Row returns a copy of row r.
+func (m *Matrix) Row(r int) Slice {
+	m.checkRow(r)
+	out := NewSlice(m.cols)
+	for c := 0; c < m.cols; c++ {
+		out.Set(c, m.cells.Get(r*m.cols+c))
+	}
+	return out
+}
+
+// Col returns a copy of column c.
+func (m *Matrix) Col(c int) Slice {
+	m.checkCol(c)
+	out := NewSlice(m.rows)
+	for r := 0; r < m.rows; r++ {
+		out.Set(r, m.cells.Get(r*m.cols+c))
+	}
+	return out
+}
+
+// --- Bulk Fills ---
+
+// Fill sets every cell to v.
+func (m *Matrix) Fill(v TriState) {
+	m.cells.fillRange(0, m.cells.Len(), v)
+}
+
+// FillRow sets every cell in row r to v.
+func (m *Matrix) FillRow(r int, v TriState) {
+	m.checkRow(r)
+	m.cells.fillRange(r*m.cols, (r+1)*m.cols, v)
+}
+
+// FillCol sets every cell in column c to v.
+func (m *Matrix) FillCol(c int, v TriState) {
+	m.checkCol(c)
+	for r := 0; r < m.rows; r++ {
+		m.cells.Set(r*m.cols+c, v)
+	}
+}
+
+func (m *Matrix) index(r, c int) int {
+	m.checkRow(r)
+	m.checkCol(c)
+	return r*m.cols + c
+}
+
+func (m *Matrix) checkRow(r int) {
+	if r < 0 || r >= m.rows {
+		panic("tristate: Matrix row out of range")
+	}
+}
+
+func (m *Matrix) checkCol(c int) {
+	if c < 0 || c >= m.cols {
+		panic("tristate: Matrix column out of range")
+	}
+}
+
+// --- JSON Marshaling ---
+
+// MarshalJSON encodes the Matrix as an array of rows, each an array of
+// true, false, and null.
+func (m Matrix) MarshalJSON() ([]byte, error) {
+	rows := make([]Slice, m.rows)
+	for r := range rows {
+		rows[r] = m.Row(r)
+	}
+	return json.Marshal(rows)
+}
+
+// UnmarshalJSON decodes an array of equal-length rows of true/false/null.
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var rows []Slice
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	cols := 0
+	if len(rows) > 0 {
+		cols = rows[0].Len()
+	}
+	out := NewMatrix(len(rows), cols)
+	for r, row := range rows {
+		if row.Len() != cols {
+			return fmt.Errorf("invalid tristate matrix: row %d has %d columns, want %d", r, row.Len(), cols)
+		}
+		for c := 0; c < cols; c++ {
+			out.Set(r, c, row.Get(c))
+		}
+	}
+	*m = out
+	return nil
+}
+
+// --- CSV Export ---
+
+// WriteCSV writes the Matrix as CSV with cells rendered as "true", "false",
+// or an empty field for None. If colNames is non-nil it is written as a
+// header row; if rowNames is non-nil each row is prefixed with its name.
+func (m *Matrix) WriteCSV(w io.Writer, rowNames, colNames []string) error {
+	if rowNames != nil && len(rowNames) != m.rows {
+		return fmt.Errorf("tristate: got %d row names for %d rows", len(rowNames), m.rows)
+	}
+	if colNames != nil && len(colNames) != m.cols {
+		return fmt.Errorf("tristate: got %d column names for %d columns", len(colNames), m.cols)
+	}
+
+	cw := csv.NewWriter(w)
+	if colNames != nil {
+		header := colNames
+		if rowNames != nil {
+			header = append([]string{""}, colNames...)
+		}
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+	record := make([]string, 0, m.cols+1)
+	for r := 0; r < m.rows; r++ {
+		record = record[:0]
+		if rowNames != nil {
+			record = append(record, rowNames[r])
+		}
+		for c := 0; c < m.cols; c++ {
+			record = append(record, csvCell(m.Get(r, c)))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvCell(v TriState) string {
+	switch v.value {
+	case True:
+		return "true"
+	case False:
+		return "false"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,132 @@
+package tristate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMatrix_GetSet(t *testing.T) {
+	m := NewMatrix(3, 4)
+	if m.Rows() != 3 || m.Cols() != 4 {
+		t.Fatalf("dimensions = %dx%d, want 3x4", m.Rows(), m.Cols())
+	}
+	m.Set(1, 2, New(true))
+	m.Set(2, 3, New(false))
+
+	if !m.Get(1, 2).IsTrue() || !m.Get(2, 3).IsFalse() || !m.Get(0, 0).IsNone() {
+		t.Error("Get returned unexpected cell values")
+	}
+
+	row := m.Row(1)
+	if row.Len() != 4 || !row.Get(2).IsTrue() || !row.Get(3).IsNone() {
+		t.Error("Row(1) returned unexpected values")
+	}
+	col := m.Col(3)
+	if col.Len() != 3 || !col.Get(2).IsFalse() || !col.Get(1).IsNone() {
+		t.Error("Col(3) returned unexpected values")
+	}
+}
+
+func TestMatrix_Fill(t *testing.T) {
+	m := NewMatrix(5, 40)
+	m.Fill(New(false))
+	m.FillRow(2, New(true))
+	m.FillCol(7, TriState{})
+
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Cols(); c++ {
+			want := New(false)
+			if r == 2 {
+				want = New(true)
+			}
+			if c == 7 {
+				want = TriState{}
+			}
+			if got := m.Get(r, c); got != want {
+				t.Fatalf("Get(%d, %d) = %v, want %v", r, c, got.value, want.value)
+			}
+		}
+	}
+}
+
+func TestMatrix_OutOfRange(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(m *Matrix)
+	}{
+		{"Row past end", func(m *Matrix) { m.Get(2, 0) }},
+		{"Col past end", func(m *Matrix) { m.Set(0, 3, New(true)) }},
+		{"Negative col", func(m *Matrix) { m.Col(-1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			m := NewMatrix(2, 3)
+			tt.fn(&m)
+		})
+	}
+}
+
+func TestMatrix_JSON(t *testing.T) {
+	m := NewMatrix(2, 3)
+	m.Set(0, 0, New(true))
+	m.Set(1, 2, New(false))
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `[[true,null,null],[null,null,false]]`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var got Matrix
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.Rows() != 2 || got.Cols() != 3 || !got.Get(0, 0).IsTrue() || !got.Get(1, 2).IsFalse() {
+		t.Error("Unmarshal did not round-trip")
+	}
+
+	if err := json.Unmarshal([]byte(`[[true],[true,false]]`), &got); err == nil {
+		t.Error("Unmarshal accepted ragged rows")
+	}
+}
+
+func TestMatrix_WriteCSV(t *testing.T) {
+	m := NewMatrix(2, 2)
+	m.Set(0, 0, New(true))
+	m.Set(1, 1, New(false))
+
+	tests := []struct {
+		name     string
+		rowNames []string
+		colNames []string
+		want     string
+	}{
+		{"Cells only", nil, nil, "true,\n,false\n"},
+		{"With labels", []string{"admin", "viewer"}, []string{"billing", "users"},
+			",billing,users\nadmin,true,\nviewer,,false\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := m.WriteCSV(&sb, tt.rowNames, tt.colNames); err != nil {
+				t.Fatalf("WriteCSV failed: %v", err)
+			}
+			if sb.String() != tt.want {
+				t.Errorf("WriteCSV = %q, want %q", sb.String(), tt.want)
+			}
+		})
+	}
+
+	if err := m.WriteCSV(&strings.Builder{}, []string{"only-one"}, nil); err == nil {
+		t.Error("WriteCSV accepted mismatched row names")
+	}
+}
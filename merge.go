@@ -0,0 +1,87 @@
+package tristate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var (
+	triStateType      = reflect.TypeFor[TriState]()
+	tracedType        = reflect.TypeFor[Traced]()
+	mapType           = reflect.TypeFor[Map]()
+	optionalValueType = reflect.TypeFor[optionalValue]()
+)
+
+// MergeStructs overlays src onto dst with override semantics: every TriState
+// field that is set in src replaces the corresponding field in dst, while
+// None fields in src leave dst untouched. Traced fields follow the same rule
+// and keep the provenance of the winning value. Optional and TriValue fields
+// of any element type replace dst whenever set in src. Map fields are
+// combined with Map.MergeOverride. Nested structs, embedded structs, and
+// non-nil pointers to structs are walked recursively; other fields are
+// ignored.
+//
+// dst must be a non-nil pointer to a struct, and src must be a struct of the
+// same type or a pointer to one.
+func MergeStructs(dst, src any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tristate: MergeStructs dst must be a non-nil struct pointer, got %T", dst)
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return nil
+		}
+		sv = sv.Elem()
+	}
+	if sv.Type() != dv.Elem().Type() {
+		return fmt.Errorf("tristate: MergeStructs type mismatch: dst %s, src %s", dv.Elem().Type(), sv.Type())
+	}
+	mergeValue(dv.Elem(), sv)
+	return nil
+}
+
+func mergeValue(dst, src reflect.Value) {
+	switch dst.Type() {
+	case triStateType:
+		if dst.CanSet() && !src.Interface().(TriState).IsNone() {
+			dst.Set(src)
+		}
+		return
+	case tracedType:
+		if dst.CanSet() && !src.Interface().(Traced).Value.IsNone() {
+			dst.Set(src)
+		}
+		return
+	case mapType:
+		if !dst.CanSet() {
+			return
+		}
+		merged := dst.Interface().(Map).MergeOverride(src.Interface().(Map))
+		dst.Set(reflect.ValueOf(merged))
+		return
+	}
+
+	if dst.Type().Implements(optionalValueType) {
+		if dst.CanSet() && src.Interface().(optionalValue).IsSet() {
+			dst.Set(src)
+		}
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			// Exported fields of an unexported embedded struct are still
+			// settable, so embedded fields are walked regardless.
+			if f := dst.Type().Field(i); f.IsExported() || f.Anonymous {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Pointer:
+		if !dst.IsNil() && !src.IsNil() && dst.Elem().Kind() == reflect.Struct {
+			mergeValue(dst.Elem(), src.Elem())
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package tristate
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Conflict records a value that ours and theirs both changed from base, to
+// different results.
+type Conflict struct {
+	// Path locates the value for Merge3Structs: the Go field path, with
+	// Map keys in brackets. It is empty for Merge3.
+	Path               string
+	Base, Ours, Theirs TriState
+}
+
+// Conflicting reports whether c describes an actual conflict. The zero
+// Conflict, returned when a merge succeeds, is not conflicting.
+func (c Conflict) Conflicting() bool { return c.Ours != c.Theirs }
+
+// Merge3 reconciles two concurrent edits of base. If only one side changed
+// the value, that change wins; if both made the same change, it is kept.
+// If both changed it to different values, Merge3 returns base unchanged
+// together with a conflicting Conflict.
+func Merge3(base, ours, theirs TriState) (TriState, Conflict) {
+	switch {
+	case ours == theirs, theirs == base:
+		return ours, Conflict{}
+	case ours == base:
+		return theirs, Conflict{}
+	default:
+		return base, Conflict{Base: base, Ours: ours, Theirs: theirs}
+	}
+}
+
+// Merge3Structs applies Merge3 to every TriState field, and every Map entry,
+// of three versions of a struct, writing the result to dst. Non-TriState
+// fields are taken from ours. Nested structs, embedded structs, and struct
+// pointers are merged recursively, with a nil pointer merging as the zero
+// struct; dst gets a nil pointer only if all three versions have one.
+// The returned conflicts are sorted by Path; conflicting fields keep their
+// base value in dst.
+//
+// dst must be a non-nil pointer to a struct, and base, ours, and theirs
+// structs of the same type or pointers to them.
+func Merge3Structs(dst, base, ours, theirs any) ([]Conflict, error) {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tristate: Merge3Structs dst must be a non-nil struct pointer, got %T", dst)
+	}
+	t := dv.Elem().Type()
+	versions := make([]reflect.Value, 3)
+	for i, v := range []any{base, ours, theirs} {
+		rv := reflect.Indirect(reflect.ValueOf(v))
+		if !rv.IsValid() || rv.Type() != t {
+			return nil, fmt.Errorf("tristate: Merge3Structs type mismatch: dst %s, got %T", t, v)
+		}
+		versions[i] = rv
+	}
+
+	dv.Elem().Set(versions[1])
+	var conflicts []Conflict
+	merge3Value(&conflicts, "", dv.Elem(), versions[0], versions[1], versions[2])
+	slices.SortFunc(conflicts, func(x, y Conflict) int { return strings.Compare(x.Path, y.Path) })
+	return conflicts, nil
+}
+
+func merge3Value(conflicts *[]Conflict, path string, dst, base, ours, theirs reflect.Value) {
+	switch dst.Type() {
+	case triStateType:
+		v, c := Merge3(base.Interface().(TriState), ours.Interface().(TriState), theirs.Interface().(TriState))
+		if c.Conflicting() {
+			c.Path = path
+			*conflicts = append(*conflicts, c)
+		}
+		if dst.CanSet() {
+			dst.Set(reflect.ValueOf(v))
+		}
+		return
+	case mapType:
+		b, o, th := base.Interface().(Map), ours.Interface().(Map), theirs.Interface().(Map)
+		out := Map{}
+		for k := range b.MergeOverride(o).MergeOverride(th) {
+			v, c := Merge3(b[k], o[k], th[k])
+			if c.Conflicting() {
+				c.Path = fmt.Sprintf("%s[%s]", path, k)
+				*conflicts = append(*conflicts, c)
+			}
+			if !v.IsNone() {
+				out[k] = v
+			}
+		}
+		if dst.CanSet() {
+			dst.Set(reflect.ValueOf(out))
+		}
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			sf := dst.Type().Field(i)
+			if !sf.IsExported() && !sf.Anonymous {
+				continue
+			}
+			merge3Value(conflicts, path+fieldSep(path)+sf.Name, dst.Field(i), base.Field(i), ours.Field(i), theirs.Field(i))
+		}
+	case reflect.Pointer:
+		if dst.Type().Elem().Kind() != reflect.Struct || (base.IsNil() && ours.IsNil() && theirs.IsNil()) {
+			return
+		}
+		// A nil side merges as the zero struct, so a sub-struct allocated
+		// by only one side still has its changes applied.
+		zero := reflect.New(dst.Type().Elem())
+		for _, v := range []*reflect.Value{&base, &ours, &theirs} {
+			if v.IsNil() {
+				*v = zero
+			}
+		}
+		// dst aliases ours here; give it its own copy before writing.
+		cp := reflect.New(dst.Type().Elem())
+		cp.Elem().Set(ours.Elem())
+		if dst.CanSet() {
+			dst.Set(cp)
+		}
+		merge3Value(conflicts, path, cp.Elem(), base.Elem(), ours.Elem(), theirs.Elem())
+	}
+}
+
+func fieldSep(path string) string {
+	if path == "" {
+		return ""
+	}
+	return "."
+}
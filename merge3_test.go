@@ -0,0 +1,140 @@
+package tristate
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	T, F, N := New(true), New(false), TriState{}
+	tests := []struct {
+		name               string
+		base, ours, theirs TriState
+		want               TriState
+		conflict           bool
+	}{
+		{"Nobody changed", T, T, T, T, false},
+		{"Ours changed", T, F, T, F, false},
+		{"Theirs changed", T, T, N, N, false},
+		{"Same change", N, F, F, F, false},
+		{"Both cleared", T, N, N, N, false},
+		{"Different changes", N, T, F, N, true},
+		{"Cleared vs flipped", T, N, F, T, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, c := Merge3(tt.base, tt.ours, tt.theirs)
+			if got != tt.want {
+				t.Errorf("Merge3() = %v, want %v", got.value, tt.want.value)
+			}
+			if c.Conflicting() != tt.conflict {
+				t.Errorf("Conflicting() = %v, want %v", c.Conflicting(), tt.conflict)
+			}
+			if tt.conflict && (c.Base != tt.base || c.Ours != tt.ours || c.Theirs != tt.theirs) {
+				t.Errorf("Conflict = %+v", c)
+			}
+		})
+	}
+}
+
+type merge3Nested struct {
+	TLS TriState
+}
+
+type merge3Config struct {
+	Audit  TriState
+	Beta   TriState
+	Name   string
+	Flags  Map
+	Nested merge3Nested
+	Ptr    *merge3Nested
+}
+
+func TestMerge3Structs(t *testing.T) {
+	base := merge3Config{
+		Audit: New(true), Name: "base",
+		Flags:  Map{"a": New(true), "b": New(true)},
+		Nested: merge3Nested{TLS: New(true)},
+		Ptr:    &merge3Nested{},
+	}
+	ours := merge3Config{
+		Audit: New(false), Beta: New(true), Name: "ours",
+		Flags:  Map{"a": New(false), "b": New(true)},
+		Nested: merge3Nested{TLS: New(true)},
+		Ptr:    &merge3Nested{TLS: New(true)},
+	}
+	theirs := merge3Config{
+		Audit: New(true), Beta: New(false), Name: "theirs",
+		Flags:  Map{"a": New(true), "c": New(true)},
+		Nested: merge3Nested{TLS: New(false)},
+		Ptr:    &merge3Nested{},
+	}
+
+	var got merge3Config
+	conflicts, err := Merge3Structs(&got, base, &ours, theirs)
+	if err != nil {
+		t.Fatalf("Merge3Structs failed: %v", err)
+	}
+
+	if len(conflicts) != 1 || conflicts[0].Path != "Beta" {
+		t.Fatalf("conflicts = %+v, want one on Beta", conflicts)
+	}
+	if !got.Audit.IsFalse() || !got.Beta.IsNone() || got.Name != "ours" || !got.Nested.TLS.IsFalse() || !got.Ptr.TLS.IsTrue() {
+		t.Errorf("merged = %+v", got)
+	}
+	if got.Ptr == ours.Ptr {
+		t.Error("merged pointer aliases ours")
+	}
+	if want := (Map{"a": New(false), "c": New(true)}); !maps.Equal(got.Flags, want) {
+		t.Errorf("Flags = %v, want %v", got.Flags, want)
+	}
+}
+
+func TestMerge3Structs_MapConflict(t *testing.T) {
+	var got merge3Config
+	conflicts, err := Merge3Structs(&got,
+		merge3Config{Flags: Map{}},
+		merge3Config{Flags: Map{"x": New(true)}},
+		merge3Config{Flags: Map{"x": New(false)}})
+	if err != nil {
+		t.Fatalf("Merge3Structs failed: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Path != "Flags[x]" {
+		t.Errorf("conflicts = %+v, want one on Flags[x]", conflicts)
+	}
+
+	if _, err := Merge3Structs(&got, merge3Config{}, merge3Nested{}, merge3Config{}); err == nil {
+		t.Error("Merge3Structs accepted mismatched types")
+	}
+}
+
+func TestMerge3Structs_NilPointer(t *testing.T) {
+	var got merge3Config
+	theirs := merge3Config{Ptr: &merge3Nested{TLS: New(true)}}
+	conflicts, err := Merge3Structs(&got, merge3Config{}, merge3Config{}, theirs)
+	if err != nil {
+		t.Fatalf("Merge3Structs failed: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts = %+v, want none", conflicts)
+	}
+	if got.Ptr == nil || !got.Ptr.TLS.IsTrue() {
+		t.Errorf("Ptr = %+v, want theirs' TLS", got.Ptr)
+	}
+	if got.Ptr == theirs.Ptr {
+		t.Error("merged pointer aliases theirs")
+	}
+
+	conflicts, err = Merge3Structs(&got, merge3Config{},
+		merge3Config{Ptr: &merge3Nested{TLS: New(false)}}, theirs)
+	if err != nil {
+		t.Fatalf("Merge3Structs failed: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Path != "Ptr.TLS" {
+		t.Errorf("conflicts = %+v, want one on Ptr.TLS", conflicts)
+	}
+
+	if _, err := Merge3Structs(&got, merge3Config{}, merge3Config{}, merge3Config{}); err != nil || got.Ptr != nil {
+		t.Errorf("Merge3Structs of nil pointers = %+v, %v; want nil", got.Ptr, err)
+	}
+}
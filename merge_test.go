@@ -0,0 +1,96 @@
+package tristate
+
+import (
+	"maps"
+	"testing"
+)
+
+type mergeLimits struct {
+	Burst TriState
+}
+
+type mergeConfig struct {
+	Audit   TriState
+	Beta    TriState
+	Name    string
+	Flags   Map
+	Limits  mergeLimits
+	Backup  *mergeLimits
+	private TriState
+	mergeEmbedded
+}
+
+type mergeEmbedded struct {
+	Verbose TriState
+}
+
+func TestMergeStructs(t *testing.T) {
+	dst := mergeConfig{
+		Audit:  New(true),
+		Beta:   New(false),
+		Name:   "base",
+		Flags:  Map{"a": New(true)},
+		Backup: &mergeLimits{Burst: New(false)},
+	}
+	src := mergeConfig{
+		Audit:         New(false),
+		Name:          "ignored",
+		Flags:         Map{"b": New(false)},
+		Limits:        mergeLimits{Burst: New(true)},
+		Backup:        &mergeLimits{Burst: New(true)},
+		private:       New(true),
+		mergeEmbedded: mergeEmbedded{Verbose: New(true)},
+	}
+
+	if err := MergeStructs(&dst, &src); err != nil {
+		t.Fatalf("MergeStructs failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  TriState
+		want State
+	}{
+		{"Set src overrides", dst.Audit, False},
+		{"None src keeps dst", dst.Beta, False},
+		{"Nested struct", dst.Limits.Burst, True},
+		{"Nested pointer", dst.Backup.Burst, True},
+		{"Embedded struct", dst.Verbose, True},
+		{"Unexported field ignored", dst.private, None},
+	}
+	for _, tt := range tests {
+		if tt.got.value != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got.value, tt.want)
+		}
+	}
+	if dst.Name != "base" {
+		t.Errorf("non-TriState field changed to %q", dst.Name)
+	}
+	if want := (Map{"a": New(true), "b": New(false)}); !maps.Equal(dst.Flags, want) {
+		t.Errorf("Flags = %v, want %v", dst.Flags, want)
+	}
+}
+
+func TestMergeStructs_Errors(t *testing.T) {
+	var cfg mergeConfig
+	tests := []struct {
+		name     string
+		dst, src any
+	}{
+		{"Non-pointer dst", cfg, cfg},
+		{"Nil dst", (*mergeConfig)(nil), cfg},
+		{"Non-struct dst", new(int), 1},
+		{"Type mismatch", &cfg, mergeLimits{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := MergeStructs(tt.dst, tt.src); err == nil {
+				t.Error("MergeStructs succeeded, want error")
+			}
+		})
+	}
+
+	if err := MergeStructs(&cfg, (*mergeConfig)(nil)); err != nil {
+		t.Errorf("MergeStructs with nil src = %v, want nil", err)
+	}
+}
@@ -0,0 +1,45 @@
+package tristate
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Param declares a tri-state request parameter extracted by Middleware.
+type Param struct {
+	Key    *Key          // key under which the value is stored in the context
+	Query  string        // query parameter to read, or "" for none
+	Header string        // header to read, or "" for none
+	Opts   []QueryOption // options passed to FromQuery
+}
+
+// Middleware parses params from each request and stores the non-None
+// values in its context under their keys, for FromContext. For each Param
+// a non-None query value takes precedence over the header. A malformed
+// query parameter is answered with 400 Bad Request without calling next.
+func Middleware(params ...Param) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			for _, p := range params {
+				var v TriState
+				if p.Query != "" {
+					var err error
+					if v, err = FromQuery(r, p.Query, p.Opts...); err != nil {
+						var qe *QueryError
+						errors.As(err, &qe)
+						http.Error(w, qe.Error(), qe.StatusCode())
+						return
+					}
+				}
+				if v.IsNone() && p.Header != "" {
+					v = ParseHeader(r.Header, p.Header)
+				}
+				if !v.IsNone() {
+					ctx = WithValue(ctx, p.Key, v)
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package tristate
+
+import (
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	params := []Param{
+		{Key: testDryRun, Query: "dry_run", Header: "X-Dry-Run"},
+		{Key: testVerbose, Query: "verbose", Opts: []QueryOption{WithEmpty(EmptyTrue)}},
+		{Key: testTrace, Header: "X-Trace"},
+	}
+	tests := []struct {
+		name       string
+		target     string
+		headers    map[string]string
+		want       Map
+		wantStatus int
+	}{
+		{"Nothing supplied", "/", nil, Map{}, http.StatusOK},
+		{"Query", "/?dry_run=1&verbose", nil, Map{"test_dry_run": New(true), "test_verbose": New(true)}, http.StatusOK},
+		{"Header fallback", "/", map[string]string{"X-Dry-Run": "false", "X-Trace": "on"}, Map{"test_dry_run": New(false), "test_trace": New(true)}, http.StatusOK},
+		{"Query beats header", "/?dry_run=true", map[string]string{"X-Dry-Run": "false"}, Map{"test_dry_run": New(true)}, http.StatusOK},
+		{"Malformed query", "/?dry_run=maybe", nil, nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Map
+			var called bool
+			h := Middleware(params...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				got = ValuesFromContext(r.Context())
+				if v, ok := FromContext(r.Context(), testDryRun); v != got["test_dry_run"] || ok != !v.IsNone() {
+					t.Errorf("FromContext(testDryRun) = %v, %v, want %v", v, ok, got["test_dry_run"])
+				}
+			}))
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if called != (tt.wantStatus == http.StatusOK) {
+				t.Fatalf("next called = %v", called)
+			}
+			if called && !maps.Equal(got, tt.want) {
+				t.Errorf("params = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
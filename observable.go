@@ -0,0 +1,198 @@
+package tristate
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// watchBuffer is the channel capacity of each Observable.Watch subscriber.
+const watchBuffer = 16
+
+// Observable is a TriState that notifies subscribers whenever its value
+// changes, so components can react to runtime flag flips without polling.
+// Notifications carry a Change with an empty Key. An Observable is safe
+// for concurrent use; the zero value holds None and is ready to use.
+type Observable struct {
+	// Clock schedules OnChangeDebounced notifications; nil means
+	// SystemClock. It must not be changed once the Observable is in use.
+	Clock Clock
+
+	// notify serializes Store calls so subscribers see changes in order.
+	// It is taken before mu, and never by Load.
+	notify sync.Mutex
+
+	mu        sync.Mutex
+	value     TriState
+	guard     Guard // consulted by TryStore
+	nextID    int
+	callbacks []observer // in registration order
+	watchers  map[chan Change]struct{}
+}
+
+type observer struct {
+	id int
+	fn func(Change)
+}
+
+// NewObservable returns an Observable holding v.
+func NewObservable(v TriState) *Observable {
+	return &Observable{value: v}
+}
+
+// Load returns the current value.
+func (o *Observable) Load() TriState {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.value
+}
+
+// Store sets the value to v. If that changes it, Store calls every OnChange
+// callback in registration order and then offers the Change to every Watch
+// channel, before returning. Callbacks may call Load but not Store.
+func (o *Observable) Store(v TriState) {
+	o.notify.Lock()
+	defer o.notify.Unlock()
+	o.store(v)
+}
+
+// store sets the value and notifies subscribers. o.notify must be held.
+func (o *Observable) store(v TriState) {
+	o.mu.Lock()
+	old := o.value
+	o.value = v
+	callbacks := o.callbacks
+	o.mu.Unlock()
+
+	if old == v {
+		return
+	}
+	c := newChange("", old, v)
+	for _, cb := range callbacks {
+		cb.fn(c)
+	}
+	for ch := range o.watchers {
+		select {
+		case ch <- c:
+		default: // the subscriber is behind; drop rather than block Store
+		}
+	}
+}
+
+// OnChange registers fn to be called synchronously by Store on every
+// change. The returned function unregisters it.
+func (o *Observable) OnChange(fn func(Change)) (cancel func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	id := o.nextID
+	o.nextID++
+	o.callbacks = append(o.callbacks, observer{id: id, fn: fn})
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		// Build a new slice: a concurrent Store may be iterating the old one.
+		o.callbacks = slices.DeleteFunc(slices.Clone(o.callbacks), func(cb observer) bool { return cb.id == id })
+	}
+}
+
+// OnChangeDebounced registers fn to be called once changes have stopped
+// arriving for window, with a single Change from the value before the burst
+// to the value after it. This coalesces flip-flopping during config reload
+// storms into one notification; a burst that ends where it started
+// delivers nothing. fn runs on its own goroutine, never concurrently with
+// itself. The returned function unregisters fn and discards any pending
+// notification.
+func (o *Observable) OnChangeDebounced(window time.Duration, fn func(Change)) (cancel func()) {
+	d := &debouncer{clock: o.Clock, window: window, fn: fn}
+	unregister := o.OnChange(d.add)
+	return func() {
+		unregister()
+		d.stop()
+	}
+}
+
+// debouncer accumulates the changes of a burst for OnChangeDebounced.
+type debouncer struct {
+	clock  Clock
+	window time.Duration
+	fn     func(Change)
+
+	deliver sync.Mutex // serializes calls to fn; taken before mu
+
+	mu            sync.Mutex
+	pending       bool
+	before, after TriState
+	timer         Timer
+	stopped       bool
+}
+
+func (d *debouncer) add(c Change) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		return
+	}
+	if !d.pending {
+		d.pending = true
+		d.before = c.Before
+	}
+	d.after = c.After
+	if d.timer == nil {
+		d.timer = afterFunc(d.clock, d.window, d.fire)
+	} else {
+		d.timer.Reset(d.window)
+	}
+}
+
+func (d *debouncer) fire() {
+	d.deliver.Lock()
+	defer d.deliver.Unlock()
+
+	d.mu.Lock()
+	if d.stopped || !d.pending {
+		d.mu.Unlock()
+		return
+	}
+	d.pending = false
+	before, after := d.before, d.after
+	d.mu.Unlock()
+
+	if before != after {
+		d.fn(newChange("", before, after))
+	}
+}
+
+func (d *debouncer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopped = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+}
+
+// Watch returns a channel receiving every subsequent change until ctx is
+// done, when the channel is closed. The channel is buffered; if the
+// receiver falls more than a buffer behind, further changes are dropped
+// until it catches up, so receivers that must not miss a transition should
+// use OnChange instead.
+func (o *Observable) Watch(ctx context.Context) <-chan Change {
+	ch := make(chan Change, watchBuffer)
+	o.notify.Lock()
+	if o.watchers == nil {
+		o.watchers = map[chan Change]struct{}{}
+	}
+	o.watchers[ch] = struct{}{}
+	o.notify.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		o.notify.Lock()
+		defer o.notify.Unlock()
+		delete(o.watchers, ch)
+		close(ch)
+	}()
+	return ch
+}
@@ -0,0 +1,109 @@
+package tristate
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestObservable_OnChange(t *testing.T) {
+	o := NewObservable(TriState{})
+	var got []Change
+	cancel := o.OnChange(func(c Change) {
+		if o.Load() != c.After {
+			t.Errorf("Load() inside callback = %v, want %v", o.Load(), c.After)
+		}
+		got = append(got, c)
+	})
+	var order []int
+	o.OnChange(func(Change) { order = append(order, 1) })
+	o.OnChange(func(Change) { order = append(order, 2) })
+
+	o.Store(New(true))
+	o.Store(New(true)) // unchanged: no notification
+	o.Store(New(false))
+	cancel()
+	cancel() // idempotent
+	o.Store(TriState{})
+
+	want := []Change{
+		{Kind: Added, After: New(true)},
+		{Kind: Changed, Before: New(true), After: New(false)},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("changes = %+v, want %+v", got, want)
+	}
+	if !slices.Equal(order, []int{1, 2, 1, 2, 1, 2}) {
+		t.Errorf("callback order = %v", order)
+	}
+}
+
+func TestObservable_Watch(t *testing.T) {
+	var o Observable
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := o.Watch(ctx)
+
+	o.Store(New(false))
+	o.Store(TriState{})
+	for _, want := range []Change{
+		{Kind: Added, After: New(false)},
+		{Kind: Removed, Before: New(false)},
+	} {
+		select {
+		case c := <-ch:
+			if c != want {
+				t.Errorf("received %+v, want %+v", c, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a change")
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("received a change after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+	o.Store(New(true)) // must not panic on the closed channel
+}
+
+func TestObservable_WatchSlowReceiver(t *testing.T) {
+	var o Observable
+	ch := o.Watch(t.Context())
+	for i := range watchBuffer + 5 {
+		o.Store(New(i%2 == 0))
+	}
+	if len(ch) != watchBuffer {
+		t.Errorf("buffered %d changes, want %d", len(ch), watchBuffer)
+	}
+}
+
+func TestObservable_Concurrent(t *testing.T) {
+	var o Observable
+	var mu sync.Mutex
+	var last TriState
+	o.OnChange(func(c Change) {
+		mu.Lock()
+		defer mu.Unlock()
+		if c.Before != last {
+			t.Errorf("change %+v does not follow %v", c, last)
+		}
+		last = c.After
+	})
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Go(func() {
+			for j := range 200 {
+				o.Store(New((i+j)%2 == 0))
+				o.Load()
+			}
+		})
+	}
+	wg.Wait()
+}
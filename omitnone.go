@@ -0,0 +1,62 @@
+package tristate
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// MarshalOmitNone encodes v like json.Marshal but leaves out every TriState
+// field that is None and every Presence field that was not provided, at
+// any depth of nested structs. A Presence provided as None stays an
+// explicit null, so a PATCH body can still clear a field.
+//
+// Tag the fields without omitzero and the same struct serves both shapes:
+// json.Marshal gives the full representation with explicit nulls for a GET
+// response, and MarshalOmitNone gives the sparse one. v must encode to a
+// JSON object; its members are emitted in sorted order.
+func MarshalOmitNone(v any) ([]byte, error) {
+	obj, err := toJSONObject(v)
+	if err != nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || !isPlainStruct(rv.Type()) {
+		return nil, fmt.Errorf("tristate: MarshalOmitNone requires a struct, got %T", v)
+	}
+	omitNone(obj, addressable(rv))
+	return json.Marshal(obj)
+}
+
+// omitNone deletes from obj the members of the struct v that are absent.
+func omitNone(obj map[string]any, v reflect.Value) {
+	for name, fv := range jsonFields(v) {
+		if _, ok := obj[name]; !ok {
+			continue
+		}
+		switch x := fv.Interface().(type) {
+		case TriState:
+			if x.IsNone() {
+				delete(obj, name)
+			}
+			continue
+		case Presence:
+			if !x.WasProvided() {
+				delete(obj, name)
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if sub, ok := obj[name].(map[string]any); ok && isPlainStruct(fv.Type()) {
+			omitNone(sub, fv)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalOmitNone(t *testing.T) {
+	type Limits struct {
+		Strict TriState `json:"strict"`
+		Audit  TriState `json:"audit"`
+	}
+	type Settings struct {
+		Name     string   `json:"name"`
+		DarkMode TriState `json:"dark_mode"`
+		Beta     TriState `json:"beta"`
+		Limits   *Limits  `json:"limits"`
+		Nested   Limits
+		Clear    Presence `json:"clear"`
+		Skip     Presence `json:"skip"`
+	}
+	s := Settings{
+		Name:     "acme",
+		DarkMode: New(true),
+		Limits:   &Limits{Audit: New(false)},
+		Clear:    Provided(TriState{}),
+	}
+
+	full, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFull := `{"name":"acme","dark_mode":true,"beta":null,"limits":{"strict":null,"audit":false},` +
+		`"Nested":{"strict":null,"audit":null},"clear":null,"skip":null}`
+	if string(full) != wantFull {
+		t.Errorf("json.Marshal() = %s, want %s", full, wantFull)
+	}
+
+	for name, v := range map[string]any{"value": s, "pointer": &s} {
+		t.Run(name, func(t *testing.T) {
+			got, err := MarshalOmitNone(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := `{"Nested":{},"clear":null,"dark_mode":true,"limits":{"audit":false},"name":"acme"}`
+			if string(got) != want {
+				t.Errorf("MarshalOmitNone() = %s, want %s", got, want)
+			}
+		})
+	}
+
+	if _, err := MarshalOmitNone(TriState{}); err == nil {
+		t.Error("MarshalOmitNone(TriState) succeeded, want error")
+	}
+}
@@ -0,0 +1,49 @@
+package tristate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrAlreadySet is returned by Once.Set for a value conflicting with the
+// one already set.
+var ErrAlreadySet = errors.New("tristate: value already set")
+
+// ConflictMode selects how Once treats a Set conflicting with the first.
+type ConflictMode uint8
+
+const (
+	ConflictError  ConflictMode = iota // return ErrAlreadySet (the default)
+	ConflictIgnore                     // keep the first value and return nil
+)
+
+// Once is a TriState that can be set explicitly only once, for "first
+// source to answer" initialization where several goroutines race to
+// supply a value. Set calls with None are no-ops, and repeating the value
+// already set is not a conflict. A Once is safe for concurrent use; the
+// zero value holds None and reports conflicts as errors.
+type Once struct {
+	// Mode selects how conflicting Sets are handled. It must not be
+	// changed once the Once is in use.
+	Mode ConflictMode
+
+	v Atomic
+}
+
+// Set stores v if no explicit value has been set yet. Otherwise a
+// different explicit v is a conflict, handled according to Mode.
+func (o *Once) Set(v TriState) error {
+	if v.IsNone() || o.v.CompareAndSwap(TriState{}, v) {
+		return nil
+	}
+	if cur := o.v.Load(); cur != v && o.Mode == ConflictError {
+		return fmt.Errorf("%w: have %v, got %v", ErrAlreadySet, cur, v)
+	}
+	return nil
+}
+
+// Load returns the value set, or None if none has been.
+func (o *Once) Load() TriState { return o.v.Load() }
+
+// IsSet reports whether an explicit value has been set.
+func (o *Once) IsSet() bool { return !o.v.Load().IsNone() }
@@ -0,0 +1,63 @@
+package tristate
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestOnce(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    ConflictMode
+		sets    []TriState
+		want    State
+		wantErr bool
+	}{
+		{"Unset", ConflictError, nil, None, false},
+		{"None is a no-op", ConflictError, []TriState{{}, New(false)}, False, false},
+		{"First wins", ConflictError, []TriState{New(true), New(true)}, True, false},
+		{"Conflict errors", ConflictError, []TriState{New(true), New(false)}, True, true},
+		{"Conflict ignored", ConflictIgnore, []TriState{New(true), New(false)}, True, false},
+		{"None after set", ConflictError, []TriState{New(false), {}}, False, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Once{Mode: tt.mode}
+			var err error
+			for _, v := range tt.sets {
+				if e := o.Set(v); e != nil {
+					err = e
+				}
+			}
+			if (err != nil) != tt.wantErr || err != nil && !errors.Is(err, ErrAlreadySet) {
+				t.Errorf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := o.Load(); got.value != tt.want || o.IsSet() != (tt.want != None) {
+				t.Errorf("Load() = %v, IsSet() = %v, want %v", got.value, o.IsSet(), tt.want)
+			}
+		})
+	}
+}
+
+func TestOnce_Race(t *testing.T) {
+	var o Once
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := range 16 {
+		wg.Go(func() { errs <- o.Set(New(i%2 == 0)) })
+	}
+	wg.Wait()
+	close(errs)
+
+	// Exactly the goroutines supplying the other value fail.
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed != 8 {
+		t.Errorf("%d Sets failed, want 8", failed)
+	}
+}
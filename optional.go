@@ -0,0 +1,188 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Optional is a value of any type that may be unset, extending the "unset
+// vs explicit" distinction of TriState to strings, numbers, and structs.
+// The zero value is unset. Optional[bool] converts to and from TriState
+// with ToTriState and TriState.Optional.
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// optional marks Optional, and types embedding it, for MergeStructs.
+func (o Optional[T]) optional() {}
+
+// optionalValue is implemented by every Optional and TriValue type.
+type optionalValue interface {
+	optional()
+	IsSet() bool
+}
+
+// --- Factory Methods ---
+
+// Of returns an Optional holding v.
+func Of[T any](v T) Optional[T] { return Optional[T]{value: v, set: true} }
+
+// OptionalFromPtr converts a *T to an Optional, mapping nil to unset.
+func OptionalFromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return Optional[T]{}
+	}
+	return Of(*p)
+}
+
+// --- Accessors ---
+
+// IsSet reports whether o holds a value.
+func (o Optional[T]) IsSet() bool { return o.set }
+
+// Get returns the value and whether it is set.
+func (o Optional[T]) Get() (val T, ok bool) { return o.value, o.set }
+
+// ValueOr returns the value if set, otherwise defaultVal.
+func (o Optional[T]) ValueOr(defaultVal T) T {
+	if o.set {
+		return o.value
+	}
+	return defaultVal
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if unset.
+func (o Optional[T]) Ptr() *T {
+	if !o.set {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// MapOptional applies f to the value of o, if set.
+func MapOptional[T, U any](o Optional[T], f func(T) U) Optional[U] {
+	if !o.set {
+		return Optional[U]{}
+	}
+	return Of(f(o.value))
+}
+
+// --- Conversions ---
+
+// Optional returns t as an Optional[bool], unset for None.
+func (t TriState) Optional() Optional[bool] {
+	b, ok := t.Bool()
+	return Optional[bool]{value: b, set: ok}
+}
+
+// ToTriState converts an Optional[bool] to a TriState, unset becoming None.
+func ToTriState(o Optional[bool]) TriState {
+	if !o.set {
+		return TriState{}
+	}
+	return New(o.value)
+}
+
+// --- Text ---
+
+// String formats the value with fmt, or returns "" if unset. For
+// string-kinded T an explicit "" formats the same way; Set("") reads it
+// back as explicit, so text cannot carry an unset string.
+func (o Optional[T]) String() string {
+	if !o.set {
+		return ""
+	}
+	return fmt.Sprint(o.value)
+}
+
+// Set parses s into the value, so an Optional can be a command-line flag
+// or envconfig field. Strings are stored as given, so Set("") makes an
+// explicit empty string; for every other type the empty string unsets o.
+// Types implementing encoding.TextUnmarshaler parse themselves; bools
+// (with Parse, so "none" also unsets) and numbers are parsed with strconv.
+// Use Clear to unset an Optional of any type.
+func (o *Optional[T]) Set(s string) error {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	if s == "" && rv.Kind() != reflect.String {
+		o.Clear()
+		return nil
+	}
+	if u, ok := any(&v).(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+		*o = Of(v)
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		t, err := Parse(s)
+		if err != nil {
+			return err
+		}
+		*o = Optional[T]{}
+		if b, ok := t.Bool(); ok {
+			rv.SetBool(b)
+			*o = Of(v)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 0, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("tristate: cannot parse text into Optional[%s]", rv.Type())
+	}
+	*o = Of(v)
+	return nil
+}
+
+// Clear unsets o.
+func (o *Optional[T]) Clear() { *o = Optional[T]{} }
+
+// --- JSON Marshaling ---
+
+// MarshalJSON encodes the value, or null if unset.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as unset and anything else as the value.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Optional[T]{}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Of(v)
+	return nil
+}
@@ -0,0 +1,146 @@
+package tristate
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestOptional(t *testing.T) {
+	var unset Optional[int]
+	if unset.IsSet() || unset.ValueOr(7) != 7 || unset.Ptr() != nil {
+		t.Errorf("zero Optional = %+v", unset)
+	}
+	o := Of(0)
+	if v, ok := o.Get(); !ok || v != 0 || o.ValueOr(7) != 0 || *o.Ptr() != 0 {
+		t.Errorf("Of(0) = %+v", o)
+	}
+
+	n := 3
+	if got := OptionalFromPtr(&n); got != Of(3) {
+		t.Errorf("OptionalFromPtr(&3) = %+v", got)
+	}
+	if got := OptionalFromPtr[int](nil); got.IsSet() {
+		t.Errorf("OptionalFromPtr(nil) = %+v", got)
+	}
+
+	if got := MapOptional(Of(42), strconv.Itoa); got != Of("42") {
+		t.Errorf("MapOptional(42) = %+v", got)
+	}
+	if got := MapOptional(unset, strconv.Itoa); got.IsSet() {
+		t.Errorf("MapOptional(unset) = %+v", got)
+	}
+}
+
+func TestOptional_TriState(t *testing.T) {
+	tests := []struct {
+		t TriState
+		o Optional[bool]
+	}{
+		{New(true), Of(true)},
+		{New(false), Of(false)},
+		{TriState{}, Optional[bool]{}},
+	}
+	for _, tt := range tests {
+		if got := tt.t.Optional(); got != tt.o {
+			t.Errorf("%v.Optional() = %+v, want %+v", tt.t, got, tt.o)
+		}
+		if got := ToTriState(tt.o); got != tt.t {
+			t.Errorf("ToTriState(%+v) = %v, want %v", tt.o, got, tt.t)
+		}
+	}
+}
+
+func TestOptional_JSON(t *testing.T) {
+	type config struct {
+		Name    Optional[string] `json:"name"`
+		Retries Optional[int]    `json:"retries"`
+	}
+	var c config
+	if err := json.Unmarshal([]byte(`{"name": "", "retries": null}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != Of("") || c.Retries.IsSet() {
+		t.Errorf("decoded %+v", c)
+	}
+	out, err := json.Marshal(config{Retries: Of(3)})
+	if err != nil || string(out) != `{"name":null,"retries":3}` {
+		t.Errorf("Marshal() = %s, %v", out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"retries": "x"}`), &c); err == nil {
+		t.Error("Unmarshal accepted a string for Optional[int]")
+	}
+}
+
+func TestOptional_TextRoundTrip(t *testing.T) {
+	for _, o := range []Optional[string]{Of(""), Of("x")} {
+		var got Optional[string]
+		if err := got.Set(o.String()); err != nil || got != o {
+			t.Errorf("Set(%q) = %+v, %v; want %+v", o.String(), got, err, o)
+		}
+	}
+
+	v := Explicit("")
+	var got TriValue[string]
+	if err := got.Set(v.String()); err != nil || !got.IsExplicitZero() {
+		t.Errorf("text round trip of Explicit(\"\") = %+v, %v", got, err)
+	}
+
+	got.Clear()
+	if got.IsSet() {
+		t.Error("Clear() left the value set")
+	}
+}
+
+func TestOptional_Set(t *testing.T) {
+	var s Optional[string]
+	var i Optional[int8]
+	var f Optional[float64]
+	var b Optional[bool]
+	var d Optional[time.Duration]
+	var a Optional[netip.Addr]
+
+	tests := []struct {
+		name    string
+		set     func() error
+		ok      func() bool // checks the result after a successful Set
+		wantErr bool
+	}{
+		{"String", func() error { return s.Set("hello") }, func() bool { return s == Of("hello") }, false},
+		{"Empty string is explicit", func() error { return s.Set("") }, func() bool { return s == Of("") }, false},
+		{"Empty number unsets", func() error { i = Of(int8(1)); return i.Set("") }, func() bool { return !i.IsSet() }, false},
+		{"Int", func() error { return i.Set("-12") }, func() bool { return i == Of(int8(-12)) }, false},
+		{"Int overflow", func() error { return i.Set("300") }, nil, true},
+		{"Float", func() error { return f.Set("1.5") }, func() bool { return f == Of(1.5) }, false},
+		{"Bool", func() error { return b.Set("false") }, func() bool { return b == Of(false) }, false},
+		{"Bool none", func() error { return b.Set("none") }, func() bool { return !b.IsSet() }, false},
+		{"Bool invalid", func() error { return b.Set("yes") }, nil, true},
+		{"Kind of named type", func() error { return d.Set("5") }, func() bool { return d == Of(time.Duration(5)) }, false},
+		{"TextUnmarshaler", func() error { return a.Set("10.0.0.1") }, func() bool { return a == Of(netip.MustParseAddr("10.0.0.1")) }, false},
+		{"TextUnmarshaler error", func() error { return a.Set("nope") }, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.set()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.ok != nil && !tt.ok() {
+				t.Error("unexpected value after Set")
+			}
+		})
+	}
+
+	if got := Of(42).String(); got != "42" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Optional[int]{}).String(); got != "" {
+		t.Errorf("unset String() = %q", got)
+	}
+	var m Optional[map[string]int]
+	if err := m.Set("x"); err == nil {
+		t.Error("Set accepted text for an unsupported type")
+	}
+}
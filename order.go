@@ -0,0 +1,27 @@
+package tristate
+
+import "cmp"
+
+// TriState values have a total order, None < False < True, matching their
+// State values: "no opinion" sorts first, then the explicit values in the
+// order of false < true. Compare implements it for slices.SortFunc and the
+// like, and State exposes it as a cmp.Ordered key for min, max, and ordered
+// maps:
+//
+//	slices.SortFunc(vals, tristate.Compare)
+//	strongest := tristate.FromState(max(a.State(), b.State()))
+
+// Compare returns -1, 0, or +1 as a sorts before, with, or after b.
+func Compare(a, b TriState) int { return cmp.Compare(a.value, b.value) }
+
+// State returns the State of t, an ordered key for t.
+func (t TriState) State() State { return t.value }
+
+// FromState returns the TriState in state s. It panics if s is not None,
+// False, or True.
+func FromState(s State) TriState {
+	if s > True {
+		panic("tristate: invalid State")
+	}
+	return TriState{value: s}
+}
@@ -0,0 +1,34 @@
+package tristate
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	vals := []TriState{New(true), {}, New(false), New(true), {}}
+	slices.SortFunc(vals, Compare)
+	want := []TriState{{}, {}, New(false), New(true), New(true)}
+	if !slices.Equal(vals, want) {
+		t.Errorf("sorted = %v, want %v", vals, want)
+	}
+	if Compare(New(false), New(true)) != -1 || Compare(New(true), New(true)) != 0 || Compare(New(false), TriState{}) != 1 {
+		t.Error("Compare disagrees with None < False < True")
+	}
+}
+
+func TestState(t *testing.T) {
+	a, b := New(false), New(true)
+	if got := FromState(max(a.State(), b.State())); got != b {
+		t.Errorf("max = %v, want %v", got, b)
+	}
+	if got := FromState(min(a.State(), TriState{}.State())); !got.IsNone() {
+		t.Errorf("min = %v, want none", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("FromState(3) did not panic")
+		}
+	}()
+	FromState(3)
+}
@@ -0,0 +1,102 @@
+package tristate
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrForeignSnapshot is returned by Overlay.Rollback for a snapshot taken
+// from a different Overlay.
+var ErrForeignSnapshot = errors.New("tristate: snapshot belongs to a different Overlay")
+
+// Overlay is a mutable stack of named override layers, merged with
+// Map.MergeOverride semantics: later layers win wherever they are set. It
+// supports transactional updates through Snapshot and Rollback, so a batch
+// of overrides can be applied, validated as a whole, and reverted. An
+// Overlay is safe for concurrent use; the zero value is empty and ready to
+// use.
+type Overlay struct {
+	mu     sync.RWMutex
+	layers []overlayLayer
+}
+
+type overlayLayer struct {
+	name     string
+	settings Map
+}
+
+// OverlaySnapshot is a handle to the state of an Overlay at the time
+// Snapshot was called.
+type OverlaySnapshot struct {
+	owner  *Overlay
+	layers []overlayLayer
+}
+
+// Push adds a layer of overrides on top of the existing ones. The Overlay
+// keeps its own copy of settings.
+func (o *Overlay) Push(name string, settings Map) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.layers = append(o.layers, overlayLayer{name: name, settings: Map{}.MergeOverride(settings)})
+}
+
+// Effective returns the merged result of every layer.
+func (o *Overlay) Effective() Map {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	out := Map{}
+	for _, l := range o.layers {
+		out = out.MergeOverride(l.settings)
+	}
+	return out
+}
+
+// Lookup returns the effective value of key and the name of the topmost
+// layer that set it, or None and an empty name.
+func (o *Overlay) Lookup(key string) (TriState, string) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	for _, l := range slices.Backward(o.layers) {
+		if v := l.settings[key]; !v.IsNone() {
+			return v, l.name
+		}
+	}
+	return TriState{}, ""
+}
+
+// Source returns a Source reading the Overlay's effective values, so it
+// can take part in a Resolver.
+func (o *Overlay) Source(name string) Source {
+	return SourceFunc(name, func(key string) (TriState, error) {
+		v, _ := o.Lookup(key)
+		return v, nil
+	})
+}
+
+// Snapshot captures the current layers.
+func (o *Overlay) Snapshot() OverlaySnapshot {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return OverlaySnapshot{owner: o, layers: slices.Clip(o.layers)}
+}
+
+// Rollback restores the layers captured by s, discarding every layer pushed
+// since. A snapshot may be rolled back to more than once.
+//
+//	snap := o.Snapshot()
+//	for name, m := range batch {
+//		o.Push(name, m)
+//	}
+//	if err := validate(o.Effective()); err != nil {
+//		o.Rollback(snap)
+//	}
+func (o *Overlay) Rollback(s OverlaySnapshot) error {
+	if s.owner != o {
+		return ErrForeignSnapshot
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.layers = s.layers
+	return nil
+}
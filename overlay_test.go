@@ -0,0 +1,68 @@
+package tristate
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestOverlay_Layers(t *testing.T) {
+	var o Overlay
+	base := Map{"audit": New(true), "beta": New(false)}
+	o.Push("defaults", base)
+	o.Push("tenant", Map{"beta": New(true), "audit": {}})
+	base["audit"] = New(false) // the Overlay holds its own copy
+
+	want := Map{"audit": New(true), "beta": New(true)}
+	if got := o.Effective(); !maps.Equal(got, want) {
+		t.Errorf("Effective() = %v, want %v", got, want)
+	}
+	if v, layer := o.Lookup("beta"); !v.IsTrue() || layer != "tenant" {
+		t.Errorf("Lookup(beta) = (%v, %q), want (True, tenant)", v.value, layer)
+	}
+	if v, layer := o.Lookup("audit"); !v.IsTrue() || layer != "defaults" {
+		t.Errorf("Lookup(audit) = (%v, %q), want (True, defaults)", v.value, layer)
+	}
+
+	r := NewResolver(o.Source("overlay"))
+	if got, layer, err := r.Resolve("beta"); err != nil || !got || layer != "overlay" {
+		t.Errorf("Resolve(beta) = (%v, %q, %v)", got, layer, err)
+	}
+}
+
+func TestOverlay_Rollback(t *testing.T) {
+	var o Overlay
+	o.Push("defaults", Map{"audit": New(true)})
+	snap := o.Snapshot()
+
+	o.Push("batch-1", Map{"audit": New(false)})
+	o.Push("batch-2", Map{"beta": New(true)})
+
+	validate := func(m Map) error {
+		if m["audit"].IsFalse() {
+			return errors.New("audit must stay on")
+		}
+		return nil
+	}
+	if err := validate(o.Effective()); err == nil {
+		t.Fatal("validation unexpectedly passed")
+	}
+	if err := o.Rollback(snap); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if got, want := o.Effective(), (Map{"audit": New(true)}); !maps.Equal(got, want) {
+		t.Errorf("after Rollback Effective() = %v, want %v", got, want)
+	}
+
+	// Pushing after a rollback must not corrupt the snapshot.
+	o.Push("batch-3", Map{"beta": New(false)})
+	o.Rollback(snap)
+	if _, ok := o.Effective()["beta"]; ok {
+		t.Error("second Rollback kept a later layer")
+	}
+
+	var other Overlay
+	if err := other.Rollback(snap); !errors.Is(err, ErrForeignSnapshot) {
+		t.Errorf("Rollback of foreign snapshot = %v, want ErrForeignSnapshot", err)
+	}
+}
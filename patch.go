@@ -0,0 +1,192 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DiffStructs returns an RFC 7386 JSON Merge Patch that turns the JSON
+// encoding of before into that of after. Unchanged fields are omitted,
+// changed fields carry their new value, and fields that became None (or
+// were dropped) are emitted as an explicit null. Nested objects are diffed
+// recursively; arrays are replaced whole.
+//
+// before and after must have the same type. An empty patch is "{}".
+func DiffStructs(before, after any) ([]byte, error) {
+	if bt, at := reflect.TypeOf(before), reflect.TypeOf(after); bt != at {
+		return nil, fmt.Errorf("tristate: DiffStructs type mismatch: %v and %v", bt, at)
+	}
+	b, err := toJSONObject(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := toJSONObject(after)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(diffObjects(b, a))
+}
+
+// toJSONObject round-trips v through encoding/json into a generic object.
+func toJSONObject(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("tristate: %T does not encode to a JSON object: %w", v, err)
+	}
+	return obj, nil
+}
+
+// diffObjects returns the merge patch from before to after. A null value
+// and a missing key are equivalent, matching how None is encoded.
+func diffObjects(before, after map[string]any) map[string]any {
+	patch := map[string]any{}
+	for k, av := range after {
+		bv := before[k]
+		if bObj, ok := bv.(map[string]any); ok {
+			if aObj, ok := av.(map[string]any); ok {
+				if sub := diffObjects(bObj, aObj); len(sub) > 0 {
+					patch[k] = sub
+				}
+				continue
+			}
+		}
+		if !reflect.DeepEqual(bv, av) {
+			patch[k] = av
+		}
+	}
+	for k, bv := range before {
+		if _, ok := after[k]; !ok && bv != nil {
+			patch[k] = nil
+		}
+	}
+	return patch
+}
+
+// ApplyMergePatch applies an RFC 7386 JSON Merge Patch to the struct
+// pointed to by target. Keys absent from the patch leave their fields
+// untouched, while an explicit null resets the field: TriState becomes None,
+// pointers become nil, and other fields their zero value. Nested objects
+// are merged into struct fields recursively, and into Map fields key by
+// key, where null removes the entry. All other values are decoded with
+// encoding/json. Patch keys are matched exactly against JSON field names;
+// keys that match no field are ignored, as json.Unmarshal would.
+func ApplyMergePatch(target any, patch []byte) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tristate: ApplyMergePatch requires a non-nil struct pointer, got %T", target)
+	}
+	return applyPatchValue(rv.Elem(), patch)
+}
+
+var (
+	jsonNull        = []byte("null")
+	unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+func applyPatchValue(v reflect.Value, raw json.RawMessage) error {
+	if bytes.Equal(bytes.TrimSpace(raw), jsonNull) {
+		v.SetZero()
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if isPatchObject(raw) && json.Unmarshal(raw, &obj) == nil {
+		switch {
+		case v.Type() == mapType:
+			m := v.Interface().(Map)
+			if m == nil {
+				m = Map{}
+			}
+			for k, sub := range obj {
+				var ts TriState
+				if err := ts.UnmarshalJSON(bytes.TrimSpace(sub)); err != nil {
+					return fmt.Errorf("tristate: key %q: %w", k, err)
+				}
+				if ts.IsNone() {
+					delete(m, k)
+				} else {
+					m[k] = ts
+				}
+			}
+			v.Set(reflect.ValueOf(m))
+			return nil
+		case isPlainStruct(v.Type()):
+			return applyPatchObject(v, obj)
+		case v.Kind() == reflect.Pointer && isPlainStruct(v.Type().Elem()):
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			return applyPatchObject(v.Elem(), obj)
+		}
+	}
+	return json.Unmarshal(raw, v.Addr().Interface())
+}
+
+func applyPatchObject(v reflect.Value, obj map[string]json.RawMessage) error {
+	fields := jsonFields(v)
+	for k, raw := range obj {
+		f, ok := fields[k]
+		if !ok {
+			continue
+		}
+		if err := applyPatchValue(f, raw); err != nil {
+			return fmt.Errorf("tristate: field %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+func isPatchObject(raw []byte) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && raw[0] == '{'
+}
+
+// isPlainStruct reports whether t is a struct that decodes field by field,
+// rather than through its own UnmarshalJSON.
+func isPlainStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(unmarshalerType)
+}
+
+// jsonFields maps the JSON names of the settable fields of the struct v to
+// their values, promoting fields of embedded structs as encoding/json does.
+func jsonFields(v reflect.Value) map[string]reflect.Value {
+	out := make(map[string]reflect.Value)
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if sf.Anonymous && name == "" {
+			if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if isPlainStruct(fv.Type()) {
+				for k, sub := range jsonFields(fv) {
+					if _, ok := out[k]; !ok {
+						out[k] = sub
+					}
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() || !fv.CanSet() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		out[name] = fv
+	}
+	return out
+}
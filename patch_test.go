@@ -0,0 +1,195 @@
+package tristate
+
+import "testing"
+
+type patchLimits struct {
+	Burst TriState `json:"burst"`
+	Rate  int      `json:"rate"`
+}
+
+type patchSettings struct {
+	Name    string       `json:"name"`
+	Audit   TriState     `json:"audit"`
+	Beta    TriState     `json:"beta"`
+	Tracing TriState     `json:"tracing,omitzero"`
+	Limits  patchLimits  `json:"limits"`
+	Backup  *patchLimits `json:"backup,omitempty"`
+}
+
+func TestDiffStructs(t *testing.T) {
+	before := patchSettings{
+		Name:    "svc",
+		Audit:   New(true),
+		Beta:    New(false),
+		Tracing: New(true),
+		Limits:  patchLimits{Burst: New(true), Rate: 10},
+	}
+	tests := []struct {
+		name  string
+		after func(s *patchSettings)
+		want  string
+	}{
+		{"Unchanged", func(s *patchSettings) {}, `{}`},
+		{"Flip", func(s *patchSettings) { s.Beta = New(true) }, `{"beta":true}`},
+		{"Cleared", func(s *patchSettings) { s.Audit = TriState{} }, `{"audit":null}`},
+		{"Cleared omitzero", func(s *patchSettings) { s.Tracing = TriState{} }, `{"tracing":null}`},
+		{"Nested", func(s *patchSettings) { s.Limits.Burst = TriState{} }, `{"limits":{"burst":null}}`},
+		{"Non-tristate fields", func(s *patchSettings) { s.Name = "api"; s.Limits.Rate = 20 }, `{"limits":{"rate":20},"name":"api"}`},
+		{"Added object", func(s *patchSettings) { s.Backup = &patchLimits{Burst: New(false)} }, `{"backup":{"burst":false,"rate":0}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := before
+			tt.after(&after)
+			got, err := DiffStructs(before, after)
+			if err != nil {
+				t.Fatalf("DiffStructs failed: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("DiffStructs = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffStructs_Errors(t *testing.T) {
+	if _, err := DiffStructs(patchSettings{}, patchLimits{}); err == nil {
+		t.Error("DiffStructs accepted mismatched types")
+	}
+	if _, err := DiffStructs(New(true), New(false)); err == nil {
+		t.Error("DiffStructs accepted non-object values")
+	}
+}
+
+type patchEmbedded struct {
+	Verbose TriState `json:"verbose"`
+}
+
+type patchTarget struct {
+	Audit  TriState     `json:"audit"`
+	Beta   TriState     `json:"beta"`
+	Name   string       `json:"name"`
+	Owner  *string      `json:"owner"`
+	Flags  Map          `json:"flags"`
+	Limits patchLimits  `json:"limits"`
+	Backup *patchLimits `json:"backup"`
+	Origin Traced       `json:"origin"`
+	patchEmbedded
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	owner := "ops"
+	base := func() patchTarget {
+		return patchTarget{
+			Audit:         New(true),
+			Beta:          New(false),
+			Name:          "svc",
+			Owner:         &owner,
+			Flags:         Map{"a": New(true), "b": New(false)},
+			Limits:        patchLimits{Burst: New(true), Rate: 10},
+			Origin:        Traced{Value: New(true), Source: "env"},
+			patchEmbedded: patchEmbedded{Verbose: New(true)},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		patch string
+		check func(t *testing.T, got patchTarget)
+	}{
+		{"Absent keys untouched", `{}`, func(t *testing.T, got patchTarget) {
+			if !got.Audit.IsTrue() || !got.Beta.IsFalse() || got.Name != "svc" {
+				t.Errorf("fields changed: %+v", got)
+			}
+		}},
+		{"Null clears tristate", `{"audit":null}`, func(t *testing.T, got patchTarget) {
+			if !got.Audit.IsNone() || !got.Beta.IsFalse() {
+				t.Errorf("Audit = %v, Beta = %v", got.Audit.value, got.Beta.value)
+			}
+		}},
+		{"Value replaces", `{"beta":true,"name":"api"}`, func(t *testing.T, got patchTarget) {
+			if !got.Beta.IsTrue() || got.Name != "api" {
+				t.Errorf("Beta = %v, Name = %q", got.Beta.value, got.Name)
+			}
+		}},
+		{"Null resets other fields", `{"name":null,"owner":null}`, func(t *testing.T, got patchTarget) {
+			if got.Name != "" || got.Owner != nil {
+				t.Errorf("Name = %q, Owner = %v", got.Name, got.Owner)
+			}
+		}},
+		{"Nested struct merges", `{"limits":{"burst":null}}`, func(t *testing.T, got patchTarget) {
+			if !got.Limits.Burst.IsNone() || got.Limits.Rate != 10 {
+				t.Errorf("Limits = %+v", got.Limits)
+			}
+		}},
+		{"Nil pointer allocated", `{"backup":{"burst":false}}`, func(t *testing.T, got patchTarget) {
+			if got.Backup == nil || !got.Backup.Burst.IsFalse() {
+				t.Errorf("Backup = %+v", got.Backup)
+			}
+		}},
+		{"Map merges by key", `{"flags":{"a":null,"c":true}}`, func(t *testing.T, got patchTarget) {
+			_, hasA := got.Flags["a"]
+			if hasA || !got.Flags["b"].IsFalse() || !got.Flags["c"].IsTrue() {
+				t.Errorf("Flags = %v", got.Flags)
+			}
+		}},
+		{"Custom unmarshaler decoded whole", `{"origin":{"value":false}}`, func(t *testing.T, got patchTarget) {
+			if !got.Origin.Value.IsFalse() || got.Origin.Source != "" {
+				t.Errorf("Origin = %+v", got.Origin)
+			}
+		}},
+		{"Embedded field", `{"verbose":null}`, func(t *testing.T, got patchTarget) {
+			if !got.Verbose.IsNone() {
+				t.Errorf("Verbose = %v", got.Verbose.value)
+			}
+		}},
+		{"Unknown key ignored", `{"nope":true}`, func(t *testing.T, got patchTarget) {}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := base()
+			if err := ApplyMergePatch(&got, []byte(tt.patch)); err != nil {
+				t.Fatalf("ApplyMergePatch failed: %v", err)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestApplyMergePatch_RoundTrip(t *testing.T) {
+	before := patchSettings{Name: "svc", Audit: New(true), Tracing: New(true), Limits: patchLimits{Rate: 1}}
+	after := patchSettings{Name: "svc", Beta: New(false), Limits: patchLimits{Burst: New(true), Rate: 1}}
+
+	patch, err := DiffStructs(before, after)
+	if err != nil {
+		t.Fatalf("DiffStructs failed: %v", err)
+	}
+	got := before
+	if err := ApplyMergePatch(&got, patch); err != nil {
+		t.Fatalf("ApplyMergePatch failed: %v", err)
+	}
+	if got != after {
+		t.Errorf("round trip = %+v, want %+v", got, after)
+	}
+}
+
+func TestApplyMergePatch_Errors(t *testing.T) {
+	var target patchTarget
+	tests := []struct {
+		name   string
+		target any
+		patch  string
+	}{
+		{"Non-pointer", target, `{}`},
+		{"Invalid tristate", &target, `{"audit":"yes"}`},
+		{"Invalid map value", &target, `{"flags":{"a":1}}`},
+		{"Malformed JSON", &target, `{"audit":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ApplyMergePatch(tt.target, []byte(tt.patch)); err == nil {
+				t.Error("ApplyMergePatch succeeded, want error")
+			}
+		})
+	}
+}
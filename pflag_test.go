@@ -0,0 +1,38 @@
+package tristate
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestTriState_Pflag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want State
+	}{
+		{"Omitted", nil, None},
+		{"Bare", []string{"--audit"}, True},
+		{"Explicit true", []string{"--audit=true"}, True},
+		{"Explicit false", []string{"--audit=false"}, False},
+		{"Explicit none", []string{"--audit=none"}, None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var audit TriState
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.Var(&audit, "audit", "enable auditing")
+			fs.Lookup("audit").NoOptDefVal = "true"
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if audit.value != tt.want {
+				t.Errorf("audit = %v, want %v", audit.value, tt.want)
+			}
+			if got := fs.Lookup("audit").Value.Type(); got != "tristate" {
+				t.Errorf("Type() = %q, want tristate", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package tristate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PlanAction classifies an entry reported by Plan.
+type PlanAction uint8
+
+const (
+	NoOp         PlanAction = iota + 1 // Known after, equal to before
+	KnownChange                        // Known after, different from before
+	UnknownAfter                       // None after: computed when applied
+)
+
+// String returns "no-op", "change", or "unknown".
+func (a PlanAction) String() string {
+	switch a {
+	case NoOp:
+		return "no-op"
+	case KnownChange:
+		return "change"
+	case UnknownAfter:
+		return "unknown"
+	default:
+		return fmt.Sprintf("PlanAction(%d)", uint8(a))
+	}
+}
+
+// MarshalText encodes the action as its String form.
+func (a PlanAction) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes "no-op", "change", or "unknown".
+func (a *PlanAction) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "no-op":
+		*a = NoOp
+	case "change":
+		*a = KnownChange
+	case "unknown":
+		*a = UnknownAfter
+	default:
+		return fmt.Errorf("invalid plan action: %q", text)
+	}
+	return nil
+}
+
+// PlannedChange describes what a plan does to a single key.
+type PlannedChange struct {
+	Key    string     `json:"key"`
+	Action PlanAction `json:"action"`
+	Before TriState   `json:"before"`
+	After  TriState   `json:"after"`
+}
+
+// Plan compares current state before with planned state after in the
+// manner of an infrastructure plan, where None in after means "known only
+// after apply" rather than unset. Every key of either Map is reported,
+// sorted by key: a None or missing after value is UnknownAfter, and an
+// explicit one is NoOp or KnownChange depending on before, in which None
+// means the key does not exist yet. Use Diff when None means unset.
+func Plan(before, after Map) []PlannedChange {
+	out := make([]PlannedChange, 0, len(after))
+	for k, a := range after {
+		out = append(out, planned(k, before[k], a))
+	}
+	for k, b := range before {
+		if _, ok := after[k]; !ok {
+			out = append(out, planned(k, b, TriState{}))
+		}
+	}
+	slices.SortFunc(out, func(x, y PlannedChange) int { return strings.Compare(x.Key, y.Key) })
+	return out
+}
+
+func planned(key string, before, after TriState) PlannedChange {
+	action := KnownChange
+	switch {
+	case after.IsNone():
+		action = UnknownAfter
+	case before == after:
+		action = NoOp
+	}
+	return PlannedChange{Key: key, Action: action, Before: before, After: after}
+}
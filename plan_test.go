@@ -0,0 +1,58 @@
+package tristate
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	before := Map{"same": New(true), "flip": New(true), "computed": New(false), "dropped": New(true)}
+	after := Map{"same": New(true), "flip": New(false), "computed": {}, "create": New(true), "pending": {}}
+
+	got := Plan(before, after)
+	want := []PlannedChange{
+		{Key: "computed", Action: UnknownAfter, Before: New(false), After: TriState{}},
+		{Key: "create", Action: KnownChange, Before: TriState{}, After: New(true)},
+		{Key: "dropped", Action: UnknownAfter, Before: New(true), After: TriState{}},
+		{Key: "flip", Action: KnownChange, Before: New(true), After: New(false)},
+		{Key: "pending", Action: UnknownAfter, Before: TriState{}, After: TriState{}},
+		{Key: "same", Action: NoOp, Before: New(true), After: New(true)},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Plan() = %+v, want %+v", got, want)
+	}
+
+	if got := Plan(nil, nil); len(got) != 0 {
+		t.Errorf("Plan() of empty maps = %+v, want none", got)
+	}
+}
+
+func TestPlannedChange_JSON(t *testing.T) {
+	c := PlannedChange{Key: "tls", Action: UnknownAfter, Before: New(true)}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"key":"tls","action":"unknown","before":true,"after":null}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	plan := Plan(Map{"a": New(true), "b": New(true)}, Map{"a": New(true), "b": New(false), "c": {}})
+	data, err = json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded []PlannedChange
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !slices.Equal(decoded, plan) {
+		t.Errorf("Unmarshal = %+v, want %+v", decoded, plan)
+	}
+
+	if err := json.Unmarshal([]byte(`{"action":"maybe"}`), &c); err == nil {
+		t.Error("Unmarshal accepted an invalid action")
+	}
+}
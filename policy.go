@@ -0,0 +1,113 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Policy is a TriState spoken in access-control vocabulary: Allow (True),
+// Deny (False), and Inherit (None). It converts freely to and from
+// TriState, and its zero value is Inherit.
+type Policy TriState
+
+// --- Factory Methods ---
+
+func Allow() Policy   { return Policy(New(true)) }
+func Deny() Policy    { return Policy(New(false)) }
+func Inherit() Policy { return Policy{} }
+
+// --- Accessors ---
+
+func (p Policy) IsAllow() bool   { return p.value == True }
+func (p Policy) IsDeny() bool    { return p.value == False }
+func (p Policy) IsInherit() bool { return p.value == None }
+
+// TriState returns p as a plain TriState.
+func (p Policy) TriState() TriState { return TriState(p) }
+
+// String returns "allow", "deny", or "inherit".
+func (p Policy) String() string {
+	switch p.value {
+	case True:
+		return "allow"
+	case False:
+		return "deny"
+	default:
+		return "inherit"
+	}
+}
+
+// DenyOverrides combines ps so that any Deny wins, then any Allow. It
+// returns Inherit if every policy is Inherit, including for no policies.
+func DenyOverrides(ps ...Policy) Policy { return overrides(ps, False, True) }
+
+// AllowOverrides combines ps so that any Allow wins, then any Deny. It
+// returns Inherit if every policy is Inherit, including for no policies.
+func AllowOverrides(ps ...Policy) Policy { return overrides(ps, True, False) }
+
+func overrides(ps []Policy, winner, fallback State) Policy {
+	seen := false
+	for _, p := range ps {
+		switch p.value {
+		case winner:
+			return p
+		case fallback:
+			seen = true
+		}
+	}
+	if seen {
+		return Policy{value: fallback}
+	}
+	return Inherit()
+}
+
+// ParsePolicy converts "allow", "deny", or "inherit" to a Policy.
+func ParsePolicy(s string) (Policy, error) {
+	switch s {
+	case "allow":
+		return Allow(), nil
+	case "deny":
+		return Deny(), nil
+	case "inherit":
+		return Inherit(), nil
+	default:
+		return Policy{}, fmt.Errorf("invalid policy value: %q", s)
+	}
+}
+
+// --- Text and JSON Marshaling ---
+
+// MarshalText encodes the Policy as its String form.
+func (p Policy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText decodes "allow", "deny", or "inherit".
+func (p *Policy) UnmarshalText(text []byte) error {
+	v, err := ParsePolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// MarshalJSON encodes the Policy as "allow", "deny", or "inherit".
+func (p Policy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON decodes "allow", "deny", or "inherit". A JSON null is
+// treated as "inherit".
+func (p *Policy) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*p = Inherit()
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid policy value: %s", string(data))
+	}
+	return p.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,94 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPolicy_Conversion(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		want   TriState
+		str    string
+	}{
+		{Allow(), New(true), "allow"},
+		{Deny(), New(false), "deny"},
+		{Inherit(), TriState{}, "inherit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			if tt.policy.TriState() != tt.want || Policy(tt.want) != tt.policy {
+				t.Error("Policy and TriState do not convert losslessly")
+			}
+			if got := tt.policy.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+		})
+	}
+
+	var zero Policy
+	if !zero.IsInherit() || zero.IsAllow() || zero.IsDeny() {
+		t.Error("zero Policy is not Inherit")
+	}
+}
+
+func TestPolicy_JSON(t *testing.T) {
+	type ACL struct {
+		Read  Policy `json:"read"`
+		Write Policy `json:"write"`
+		Admin Policy `json:"admin"`
+	}
+	in := ACL{Read: Allow(), Write: Deny()}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"read":"allow","write":"deny","admin":"inherit"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var out ACL
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if out != in {
+		t.Errorf("Unmarshal = %+v, want %+v", out, in)
+	}
+
+	out.Read = Allow()
+	if err := json.Unmarshal([]byte(`{"read":null}`), &out); err != nil || !out.Read.IsInherit() {
+		t.Errorf("Unmarshal null = (%v, %v), want inherit", out.Read, err)
+	}
+
+	for _, bad := range []string{`{"read":true}`, `{"read":"permit"}`} {
+		if err := json.Unmarshal([]byte(bad), &out); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestPolicy_Overrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		ps        []Policy
+		wantDeny  Policy
+		wantAllow Policy
+	}{
+		{"Empty", nil, Inherit(), Inherit()},
+		{"All inherit", []Policy{Inherit(), Inherit()}, Inherit(), Inherit()},
+		{"Allow only", []Policy{Inherit(), Allow()}, Allow(), Allow()},
+		{"Deny only", []Policy{Deny(), Inherit()}, Deny(), Deny()},
+		{"Mixed", []Policy{Allow(), Inherit(), Deny()}, Deny(), Allow()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DenyOverrides(tt.ps...); got != tt.wantDeny {
+				t.Errorf("DenyOverrides() = %v, want %v", got, tt.wantDeny)
+			}
+			if got := AllowOverrides(tt.ps...); got != tt.wantAllow {
+				t.Errorf("AllowOverrides() = %v, want %v", got, tt.wantAllow)
+			}
+		})
+	}
+}
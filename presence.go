@@ -0,0 +1,45 @@
+package tristate
+
+// Presence is a TriState that also records whether it was provided at all,
+// for PATCH bodies where {"flag": null} clears a setting but {} leaves it
+// untouched. UnmarshalJSON marks the field provided, including for null;
+// encoding/json never calls it for a missing key, so an absent field keeps
+// the zero value, which is not provided.
+type Presence struct {
+	value    TriState
+	provided bool
+}
+
+// Provided returns a Presence holding v that was provided.
+func Provided(v TriState) Presence { return Presence{value: v, provided: true} }
+
+// TriState returns the value, None if it was not provided.
+func (p Presence) TriState() TriState { return p.value }
+
+// WasProvided reports whether the field was present, even if null.
+func (p Presence) WasProvided() bool { return p.provided }
+
+// IsZero reports whether p was not provided, so a field tagged omitzero is
+// left out of the encoding while an explicit None is kept as null.
+func (p Presence) IsZero() bool { return !p.provided }
+
+// Apply stores the value in *dst if it was provided, so an explicit null
+// clears dst to None and a missing field leaves it alone.
+func (p Presence) Apply(dst *TriState) {
+	if p.provided {
+		*dst = p.value
+	}
+}
+
+// MarshalJSON encodes the value as true, false, or null.
+func (p Presence) MarshalJSON() ([]byte, error) { return p.value.MarshalJSON() }
+
+// UnmarshalJSON decodes true, false, or null and marks p provided.
+func (p *Presence) UnmarshalJSON(data []byte) error {
+	var v TriState
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*p = Provided(v)
+	return nil
+}
@@ -0,0 +1,75 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPresence_JSON(t *testing.T) {
+	type Patch struct {
+		Flag Presence `json:"flag,omitzero"`
+	}
+
+	tests := []struct {
+		name         string
+		jsonIn       string
+		wantProvided bool
+		want         State
+	}{
+		{"Explicit true", `{"flag": true}`, true, True},
+		{"Explicit false", `{"flag": false}`, true, False},
+		{"Explicit null", `{"flag": null}`, true, None},
+		{"Missing field", `{}`, false, None},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Patch
+			if err := json.Unmarshal([]byte(tt.jsonIn), &p); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if p.Flag.WasProvided() != tt.wantProvided || p.Flag.TriState().value != tt.want {
+				t.Errorf("got (%v, %v), want (%v, %v)", p.Flag.WasProvided(), p.Flag.TriState().value, tt.wantProvided, tt.want)
+			}
+
+			out, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var back Patch
+			if err := json.Unmarshal(out, &back); err != nil {
+				t.Fatalf("Unmarshal(%s) failed: %v", out, err)
+			}
+			if back != p {
+				t.Errorf("round trip via %s = %+v, want %+v", out, back, p)
+			}
+		})
+	}
+
+	var p Presence
+	if err := p.UnmarshalJSON([]byte(`"yes"`)); err == nil || p.WasProvided() {
+		t.Errorf("UnmarshalJSON(invalid) = %v, provided %v", err, p.WasProvided())
+	}
+}
+
+func TestPresence_Apply(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Presence
+		want State
+	}{
+		{"Missing leaves value", Presence{}, True},
+		{"Null clears", Provided(TriState{}), None},
+		{"False overwrites", Provided(New(false)), False},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := New(true)
+			tt.p.Apply(&dst)
+			if dst.value != tt.want {
+				t.Errorf("Apply() = %v, want %v", dst.value, tt.want)
+			}
+		})
+	}
+}
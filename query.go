@@ -0,0 +1,114 @@
+package tristate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Reasons a query parameter is rejected, wrapped by QueryError.
+var (
+	ErrEmptyParam       = errors.New("value required")
+	ErrInvalidParam     = errors.New("want true, false, 1, 0, yes, or no")
+	ErrConflictingParam = errors.New("conflicting values")
+)
+
+// QueryError reports a query parameter FromQuery could not decode. Its
+// StatusCode is 400, so handlers can map it to a Bad Request response:
+//
+//	v, err := tristate.FromQuery(r, "audit")
+//	var qe *tristate.QueryError
+//	if errors.As(err, &qe) {
+//		http.Error(w, qe.Error(), qe.StatusCode())
+//	}
+type QueryError struct {
+	Param  string   // parameter name
+	Values []string // values as submitted
+	Err    error    // one of ErrEmptyParam, ErrInvalidParam, ErrConflictingParam
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("invalid query parameter %q=%q: %v", e.Param, strings.Join(e.Values, ","), e.Err)
+}
+
+func (e *QueryError) Unwrap() error { return e.Err }
+
+// StatusCode returns http.StatusBadRequest.
+func (e *QueryError) StatusCode() int { return http.StatusBadRequest }
+
+// EmptyMode selects how FromQuery treats a parameter given without a
+// value, as in ?audit or ?audit=.
+type EmptyMode int
+
+const (
+	EmptyNone  EmptyMode = iota // decode to None, as if absent (the default)
+	EmptyTrue                   // decode to True, treating the bare name as a switch
+	EmptyError                  // reject with ErrEmptyParam
+)
+
+// QueryOption configures FromQuery.
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	empty EmptyMode
+}
+
+// WithEmpty sets how FromQuery treats an empty value.
+func WithEmpty(m EmptyMode) QueryOption {
+	return func(o *queryOptions) { o.empty = m }
+}
+
+// queryVocabulary holds the tokens FromQuery accepts besides the empty
+// value, which is governed by EmptyMode.
+var queryVocabulary = Vocabulary{
+	True:  []string{"true", "1", "yes"},
+	False: []string{"false", "0", "no"},
+}
+
+// FromQuery reads the query parameter name of r. An absent parameter is
+// None; true/false, 1/0, and yes/no (in any case) set it explicitly, and
+// an empty value is handled according to WithEmpty. A repeated parameter
+// is accepted only if every occurrence decodes to the same value. Errors
+// are of type *QueryError.
+func FromQuery(r *http.Request, name string, opts ...QueryOption) (TriState, error) {
+	var o queryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	vals, ok := r.URL.Query()[name]
+	if !ok {
+		return TriState{}, nil
+	}
+
+	var out TriState
+	for i, raw := range vals {
+		v, err := o.parse(raw)
+		if err != nil {
+			return TriState{}, &QueryError{Param: name, Values: vals, Err: err}
+		}
+		if i > 0 && v != out {
+			return TriState{}, &QueryError{Param: name, Values: vals, Err: ErrConflictingParam}
+		}
+		out = v
+	}
+	return out, nil
+}
+
+func (o queryOptions) parse(raw string) (TriState, error) {
+	if strings.TrimSpace(raw) == "" {
+		switch o.empty {
+		case EmptyTrue:
+			return New(true), nil
+		case EmptyError:
+			return TriState{}, ErrEmptyParam
+		default:
+			return TriState{}, nil
+		}
+	}
+	v, err := queryVocabulary.Parse(raw)
+	if err != nil {
+		return TriState{}, ErrInvalidParam
+	}
+	return v, nil
+}
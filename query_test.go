@@ -0,0 +1,59 @@
+package tristate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		opts    []QueryOption
+		want    State
+		wantErr error
+	}{
+		{"Absent", "other=1", nil, None, nil},
+		{"True", "audit=true", nil, True, nil},
+		{"Numeric false", "audit=0", nil, False, nil},
+		{"Yes", "audit=YES", nil, True, nil},
+		{"No", "audit=no", nil, False, nil},
+		{"Empty default", "audit=", nil, None, nil},
+		{"Bare default", "audit", nil, None, nil},
+		{"Bare as switch", "audit", []QueryOption{WithEmpty(EmptyTrue)}, True, nil},
+		{"Empty rejected", "audit=", []QueryOption{WithEmpty(EmptyError)}, None, ErrEmptyParam},
+		{"Invalid", "audit=maybe", nil, None, ErrInvalidParam},
+		{"None is not a query token", "audit=none", nil, None, ErrInvalidParam},
+		{"Repeated agreeing", "audit=1&audit=true", nil, True, nil},
+		{"Repeated conflicting", "audit=1&audit=0", nil, None, ErrConflictingParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			got, err := FromQuery(r, "audit", tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FromQuery() error = %v, want %v", err, tt.wantErr)
+			}
+			if got.value != tt.want {
+				t.Errorf("FromQuery() = %v, want %v", got.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/?audit=maybe", nil)
+	_, err := FromQuery(r, "audit")
+	var qe *QueryError
+	if !errors.As(err, &qe) {
+		t.Fatalf("error %v is not a *QueryError", err)
+	}
+	if qe.Param != "audit" || qe.StatusCode() != http.StatusBadRequest {
+		t.Errorf("QueryError = %+v, status %d", qe, qe.StatusCode())
+	}
+	if want := `invalid query parameter "audit"="maybe": want true, false, 1, 0, yes, or no`; qe.Error() != want {
+		t.Errorf("Error() = %q, want %q", qe.Error(), want)
+	}
+}
@@ -0,0 +1,14 @@
+package tristate
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Generate implements testing/quick's Generator interface, choosing None,
+// False, and True with equal probability. Without it quick cannot build
+// TriState values, or structs containing them, because the state field is
+// unexported.
+func (TriState) Generate(rand *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(TriState{value: State(rand.Intn(3))})
+}
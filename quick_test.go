@@ -0,0 +1,32 @@
+package tristate
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestTriState_Generate(t *testing.T) {
+	type settings struct {
+		Audit TriState
+		Name  string
+	}
+	seen := map[State]int{}
+	check := func(s settings) bool {
+		seen[s.Audit.value]++
+		return s.Audit.value <= True
+	}
+	if err := quick.Check(check, &quick.Config{MaxCount: 300}); err != nil {
+		t.Fatal(err)
+	}
+	for _, st := range []State{None, False, True} {
+		if seen[st] == 0 {
+			t.Errorf("state %v never generated in 300 values", st)
+		}
+	}
+
+	// All is order-independent.
+	commutes := func(a, b TriState) bool { return All([]TriState{a, b}) == All([]TriState{b, a}) }
+	if err := quick.Check(commutes, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,45 @@
+package tristate
+
+import (
+	"iter"
+	"math/rand/v2"
+)
+
+// Random produces a reproducible stream of TriState values with fixed
+// probabilities, for simulations and load tests of flag-dependent code.
+// A Random is not safe for concurrent use.
+type Random struct {
+	r             *rand.Rand
+	pTrue, pFalse float64
+}
+
+// Rand returns a Random drawing from src, yielding True with probability
+// pTrue, False with probability pFalse, and None otherwise. The same
+// source state yields the same stream. It panics unless both
+// probabilities are non-negative and sum to at most 1.
+func Rand(src rand.Source, pTrue, pFalse float64) *Random {
+	if !(pTrue >= 0 && pFalse >= 0 && pTrue+pFalse <= 1) {
+		panic("tristate: invalid Rand probabilities")
+	}
+	return &Random{r: rand.New(src), pTrue: pTrue, pFalse: pFalse}
+}
+
+// Next returns the next value of the stream.
+func (r *Random) Next() TriState {
+	switch x := r.r.Float64(); {
+	case x < r.pTrue:
+		return New(true)
+	case x < r.pTrue+r.pFalse:
+		return New(false)
+	default:
+		return TriState{}
+	}
+}
+
+// Values returns an infinite iterator over the stream.
+func (r *Random) Values() iter.Seq[TriState] {
+	return func(yield func(TriState) bool) {
+		for yield(r.Next()) {
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package tristate
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestRand(t *testing.T) {
+	const n = 20000
+	r := Rand(rand.NewPCG(1, 2), 0.5, 0.2)
+	var trueN, falseN, noneN int
+	for range n {
+		switch r.Next().value {
+		case True:
+			trueN++
+		case False:
+			falseN++
+		default:
+			noneN++
+		}
+	}
+	for _, tc := range []struct {
+		name string
+		got  int
+		want float64
+	}{
+		{"True", trueN, 0.5},
+		{"False", falseN, 0.2},
+		{"None", noneN, 0.3},
+	} {
+		if frac := float64(tc.got) / n; math.Abs(frac-tc.want) > 0.02 {
+			t.Errorf("%s fraction = %.3f, want about %.1f", tc.name, frac, tc.want)
+		}
+	}
+}
+
+func TestRand_Reproducible(t *testing.T) {
+	take := func() []TriState {
+		var out []TriState
+		for v := range Rand(rand.NewPCG(7, 7), 0.3, 0.3).Values() {
+			if out = append(out, v); len(out) == 50 {
+				break
+			}
+		}
+		return out
+	}
+	if a, b := take(), take(); !slices.Equal(a, b) {
+		t.Error("the same seed produced different streams")
+	}
+	if v := Rand(rand.NewPCG(1, 1), 1, 0).Next(); v.value != True {
+		t.Errorf("pTrue=1 produced %v", v.value)
+	}
+}
+
+func TestRand_Invalid(t *testing.T) {
+	for _, p := range [][2]float64{{-0.1, 0}, {0.6, 0.5}, {math.NaN(), 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Rand(%v, %v) did not panic", p[0], p[1])
+				}
+			}()
+			Rand(rand.NewPCG(1, 1), p[0], p[1])
+		}()
+	}
+}
@@ -0,0 +1,60 @@
+package tristate
+
+// Reasoned is a TriState paired with why it holds, such as False because
+// "quota_exceeded", so a decision combined from many checks can explain
+// itself. And and Or keep the reason of the operand that decided the
+// result.
+type Reasoned struct {
+	Value   TriState `json:"value"`
+	Code    string   `json:"code,omitempty"`    // machine-readable reason, e.g. "quota_exceeded"
+	Message string   `json:"message,omitempty"` // human-readable explanation
+}
+
+// Because returns v explained by code and message.
+func Because(v TriState, code, message string) Reasoned {
+	return Reasoned{Value: v, Code: code, Message: message}
+}
+
+// And returns the Kleene conjunction of r and o with the reason of the
+// deciding operand; see AllReasoned.
+func (r Reasoned) And(o Reasoned) Reasoned { return AllReasoned(r, o) }
+
+// Or returns the Kleene disjunction of r and o with the reason of the
+// deciding operand; see AnyReasoned.
+func (r Reasoned) Or(o Reasoned) Reasoned { return AnyReasoned(r, o) }
+
+// AllReasoned returns the Kleene conjunction of vals, as All does, carrying
+// the reason of the first False value, or failing that the first None
+// value, or failing that the first value. AllReasoned of an empty list is
+// True with no reason.
+func AllReasoned(vals ...Reasoned) Reasoned {
+	return decide(vals, False, Reasoned{Value: New(true)})
+}
+
+// AnyReasoned returns the Kleene disjunction of vals, as Any does, carrying
+// the reason of the first True value, or failing that the first None
+// value, or failing that the first value. AnyReasoned of an empty list is
+// False with no reason.
+func AnyReasoned(vals ...Reasoned) Reasoned {
+	return decide(vals, True, Reasoned{Value: New(false)})
+}
+
+// decide returns the first value in the absorbing state, otherwise the
+// first None, otherwise the first value, or empty if there are none.
+func decide(vals []Reasoned, absorbing State, empty Reasoned) Reasoned {
+	if len(vals) == 0 {
+		return empty
+	}
+	out := vals[0]
+	for _, v := range vals {
+		switch v.Value.value {
+		case absorbing:
+			return v
+		case None:
+			if !out.Value.IsNone() {
+				out = v
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestReasoned(t *testing.T) {
+	member := Because(New(true), "member", "is a team member")
+	quota := Because(New(false), "quota_exceeded", "monthly quota used up")
+	banned := Because(New(false), "banned", "")
+	unknown := Because(TriState{}, "region_unknown", "")
+	admin := Because(New(true), "admin", "")
+
+	tests := []struct {
+		name     string
+		got      Reasoned
+		want     State
+		wantCode string
+	}{
+		{"And decided by False", member.And(quota), False, "quota_exceeded"},
+		{"And first False wins", AllReasoned(member, banned, unknown, quota), False, "banned"},
+		{"And None beats True", member.And(unknown), None, "region_unknown"},
+		{"And all True", member.And(admin), True, "member"},
+		{"And empty", AllReasoned(), True, ""},
+		{"Or decided by True", quota.Or(admin), True, "admin"},
+		{"Or None beats False", AnyReasoned(quota, unknown, banned), None, "region_unknown"},
+		{"Or all False", quota.Or(banned), False, "quota_exceeded"},
+		{"Or empty", AnyReasoned(), False, ""},
+		{"Nested", member.And(quota.Or(admin)), True, "member"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Value.value != tt.want || tt.got.Code != tt.wantCode {
+				t.Errorf("got %v (%q), want %v (%q)", tt.got.Value.value, tt.got.Code, tt.want, tt.wantCode)
+			}
+		})
+	}
+
+	// The combined value agrees with All and Any.
+	vals := []Reasoned{member, unknown, quota}
+	plain := []TriState{member.Value, unknown.Value, quota.Value}
+	if AllReasoned(vals...).Value != All(plain) || AnyReasoned(vals...).Value != Any(plain) {
+		t.Error("AllReasoned or AnyReasoned disagrees with All or Any")
+	}
+}
+
+func TestReasoned_JSON(t *testing.T) {
+	out, err := json.Marshal(Because(New(false), "quota_exceeded", "monthly quota used up"))
+	if err != nil || string(out) != `{"value":false,"code":"quota_exceeded","message":"monthly quota used up"}` {
+		t.Errorf("Marshal() = %s, %v", out, err)
+	}
+}
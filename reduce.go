@@ -0,0 +1,42 @@
+package tristate
+
+import (
+	"runtime"
+	"sync"
+)
+
+// minWordsPerWorker keeps chunks large enough that goroutine overhead does
+// not dominate small inputs.
+const minWordsPerWorker = 1024
+
+// ReduceParallel folds op over every element of s under the given logic,
+// splitting the packed words across up to workers goroutines and combining
+// their partial tallies. A workers value <= 0 uses runtime.GOMAXPROCS(0).
+// Reducing an empty Slice yields True for OpAnd and False for OpOr.
+func ReduceParallel(s *Slice, logic Logic, op Op, workers int) TriState {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	words := s.words[:wordsFor(s.n)]
+	workers = max(1, min(workers, len(words)/minWordsPerWorker))
+
+	chunk := (len(words) + workers - 1) / workers
+	partial := make([][2]int, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		lo := min(w*chunk, len(words))
+		hi := min(lo+chunk, len(words))
+		wg.Go(func() {
+			trueN, falseN := countWords(words[lo:hi])
+			partial[w] = [2]int{trueN, falseN}
+		})
+	}
+	wg.Wait()
+
+	var trueN, falseN int
+	for _, p := range partial {
+		trueN += p[0]
+		falseN += p[1]
+	}
+	return logic.reduce(op, trueN, falseN, s.n-trueN-falseN)
+}
@@ -0,0 +1,73 @@
+package tristate
+
+import "testing"
+
+func TestReduceParallel(t *testing.T) {
+	big := func(set func(i int) TriState) *Slice {
+		s := NewSlice(200_000)
+		for i := 0; i < s.Len(); i++ {
+			s.Set(i, set(i))
+		}
+		return &s
+	}
+	allTrue := big(func(int) TriState { return New(true) })
+	withNone := big(func(i int) TriState {
+		if i == 123_456 {
+			return TriState{}
+		}
+		return New(true)
+	})
+	withFalse := big(func(i int) TriState {
+		switch i {
+		case 7:
+			return TriState{}
+		case 199_999:
+			return New(false)
+		}
+		return New(true)
+	})
+	empty := &Slice{}
+
+	tests := []struct {
+		name  string
+		input *Slice
+		logic Logic
+		op    Op
+		want  State
+	}{
+		{"Kleene AND all true", allTrue, Kleene, OpAnd, True},
+		{"Kleene AND with none", withNone, Kleene, OpAnd, None},
+		{"Kleene AND false beats none", withFalse, Kleene, OpAnd, False},
+		{"Kleene OR true beats none", withNone, Kleene, OpOr, True},
+		{"Bochvar AND none infects", withFalse, Bochvar, OpAnd, None},
+		{"Bochvar OR none infects", withNone, Bochvar, OpOr, None},
+		{"Bochvar OR all true", allTrue, Bochvar, OpOr, True},
+		{"Empty AND", empty, Kleene, OpAnd, True},
+		{"Empty OR", empty, Kleene, OpOr, False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, workers := range []int{0, 1, 3, 64} {
+				if got := ReduceParallel(tt.input, tt.logic, tt.op, workers).value; got != tt.want {
+					t.Errorf("ReduceParallel(workers=%d) = %v, want %v", workers, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReduceParallel(b *testing.B) {
+	s := NewSlice(10_000_000)
+	s.fillRange(0, s.Len(), New(true))
+	for _, workers := range []int{1, 0} {
+		name := "serial"
+		if workers == 0 {
+			name = "gomaxprocs"
+		}
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				ReduceParallel(&s, Kleene, OpAnd, workers)
+			}
+		})
+	}
+}
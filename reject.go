@@ -0,0 +1,45 @@
+package tristate
+
+import "sync/atomic"
+
+// Rejection describes input refused by one of the package's decoders. It
+// is passed to the hook installed with SetRejectHook.
+type Rejection struct {
+	Decoder string // "parse", "json", or "vocabulary"
+	Input   string // the raw input, as received
+	Err     error  // the error returned to the caller
+}
+
+var rejectHook atomic.Pointer[func(Rejection)]
+
+// SetRejectHook installs fn to be called whenever Parse (and so Set),
+// UnmarshalJSON, or Vocabulary.Parse rejects its input, and returns the
+// previously installed hook. Pass nil to remove the hook. It lets
+// production services count and sample malformed values that would
+// otherwise be lost in error chains or, as in FromEnv and DecodeForm,
+// deliberately ignored:
+//
+//	tristate.SetRejectHook(func(r tristate.Rejection) {
+//		rejected.WithLabelValues(r.Decoder).Inc()
+//	})
+//
+// fn may be called concurrently from any goroutine that decodes, and must
+// not itself decode TriState values.
+func SetRejectHook(fn func(Rejection)) (previous func(Rejection)) {
+	var p *func(Rejection)
+	if fn != nil {
+		p = &fn
+	}
+	if old := rejectHook.Swap(p); old != nil {
+		return *old
+	}
+	return nil
+}
+
+// reject reports a refused input to the hook, if any, and returns err.
+func reject(decoder, input string, err error) error {
+	if fn := rejectHook.Load(); fn != nil {
+		(*fn)(Rejection{Decoder: decoder, Input: input, Err: err})
+	}
+	return err
+}
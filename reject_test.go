@@ -0,0 +1,39 @@
+package tristate
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetRejectHook(t *testing.T) {
+	var got []Rejection
+	SetRejectHook(func(r Rejection) { got = append(got, r) })
+	defer SetRejectHook(nil)
+
+	Parse(" maybe ")
+	Parse("true") // accepted: not reported
+	var v TriState
+	v.UnmarshalJSON([]byte(`"yes"`))
+	DefaultVocabulary.Parse("sometimes")
+	FromEnv("TRISTATE_TEST_UNSET_VARIABLE")
+
+	var decoders, inputs []string
+	for _, r := range got {
+		if r.Err == nil {
+			t.Errorf("rejection %+v has no error", r)
+		}
+		decoders = append(decoders, r.Decoder)
+		inputs = append(inputs, r.Input)
+	}
+	if want := []string{"parse", "json", "vocabulary"}; !slices.Equal(decoders, want) {
+		t.Errorf("decoders = %q, want %q", decoders, want)
+	}
+	if want := []string{" maybe ", `"yes"`, "sometimes"}; !slices.Equal(inputs, want) {
+		t.Errorf("inputs = %q, want %q", inputs, want)
+	}
+
+	if prev := SetRejectHook(nil); prev == nil {
+		t.Error("SetRejectHook did not return the previous hook")
+	}
+	Parse("maybe") // no hook installed: must not panic
+}
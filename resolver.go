@@ -0,0 +1,129 @@
+package tristate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrUnresolved is returned by Resolver.Resolve when no source sets a key.
+var ErrUnresolved = errors.New("tristate: setting not set by any source")
+
+// Source is one layer of configuration consulted by a Resolver, such as
+// command-line flags, environment variables, a config file, a remote store,
+// or hard-coded defaults.
+type Source interface {
+	// Name identifies the layer in resolution results, e.g. "env".
+	Name() string
+	// Lookup returns the value of key in this layer, or None if the layer
+	// has no opinion.
+	Lookup(key string) (TriState, error)
+}
+
+// --- Sources ---
+
+type funcSource struct {
+	name string
+	fn   func(key string) (TriState, error)
+}
+
+func (s funcSource) Name() string                        { return s.name }
+func (s funcSource) Lookup(key string) (TriState, error) { return s.fn(key) }
+
+// SourceFunc adapts a lookup function, such as a client for a remote
+// settings store, into a Source.
+func SourceFunc(name string, fn func(key string) (TriState, error)) Source {
+	return funcSource{name: name, fn: fn}
+}
+
+// MapSource returns a Source backed by a Map, such as one decoded from a
+// config file.
+func MapSource(name string, m Map) Source {
+	return SourceFunc(name, func(key string) (TriState, error) {
+		return m[key], nil
+	})
+}
+
+// Defaults returns a Source named "default" holding hard-coded values. It
+// is normally the last source given to a Resolver.
+func Defaults(m Map) Source {
+	return MapSource("default", m)
+}
+
+// EnvSource returns a Source named "env" that reads key from the
+// environment variable formed by prefix followed by the upper-cased key,
+// with every character other than letters and digits replaced by '_'.
+// For example, with prefix "APP_" the key "beta.enabled" is read from
+// APP_BETA_ENABLED. Values are parsed with Parse.
+func EnvSource(prefix string) Source {
+	return SourceFunc("env", func(key string) (TriState, error) {
+		name := prefix + envName(key)
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			return TriState{}, nil
+		}
+		v, err := Parse(raw)
+		if err != nil {
+			return TriState{}, fmt.Errorf("%s: %w", name, err)
+		}
+		return v, nil
+	})
+}
+
+func envName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return r - 'a' + 'A'
+		case 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// --- Resolution ---
+
+// Resolver resolves named settings against an ordered list of Sources.
+type Resolver struct {
+	sources []Source
+}
+
+// NewResolver returns a Resolver consulting sources in the order given, so
+// the first source has the highest precedence.
+func NewResolver(sources ...Source) *Resolver {
+	return &Resolver{sources: sources}
+}
+
+// Lookup returns the first explicit value for key and the name of the
+// source that supplied it. If no source sets key it returns None and an
+// empty name. A source error stops resolution.
+func (r *Resolver) Lookup(key string) (TriState, string, error) {
+	for _, src := range r.sources {
+		v, err := src.Lookup(key)
+		if err != nil {
+			return TriState{}, "", fmt.Errorf("tristate: source %q: %w", src.Name(), err)
+		}
+		if !v.IsNone() {
+			return v, src.Name(), nil
+		}
+	}
+	return TriState{}, "", nil
+}
+
+// Resolve returns the final value of key and the name of the source that
+// supplied it. It returns ErrUnresolved if no source sets key; include a
+// Defaults source to guarantee resolution.
+func (r *Resolver) Resolve(key string) (bool, string, error) {
+	v, layer, err := r.Lookup(key)
+	if err != nil {
+		return false, "", err
+	}
+	b, ok := v.Bool()
+	if !ok {
+		return false, "", fmt.Errorf("%w: %q", ErrUnresolved, key)
+	}
+	return b, layer, nil
+}
@@ -0,0 +1,65 @@
+package tristate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Setenv("APP_BETA_ENABLED", "false")
+	t.Setenv("APP_BROKEN", "sometimes")
+
+	remoteErr := errors.New("store unavailable")
+	remote := SourceFunc("remote", func(key string) (TriState, error) {
+		if key == "outage" {
+			return TriState{}, remoteErr
+		}
+		return TriState{}, nil
+	})
+	r := NewResolver(
+		MapSource("flags", Map{"audit": New(false)}),
+		EnvSource("APP_"),
+		MapSource("file", Map{"audit": New(true), "beta.enabled": New(true), "tracing": New(true)}),
+		remote,
+		Defaults(Map{"audit": New(true), "beta.enabled": New(true), "tracing": New(false), "outage": New(true)}),
+	)
+
+	tests := []struct {
+		key       string
+		wantVal   bool
+		wantLayer string
+	}{
+		{"audit", false, "flags"},
+		{"beta.enabled", false, "env"},
+		{"tracing", true, "file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, layer, err := r.Resolve(tt.key)
+			if err != nil {
+				t.Fatalf("Resolve(%q) failed: %v", tt.key, err)
+			}
+			if got != tt.wantVal || layer != tt.wantLayer {
+				t.Errorf("Resolve(%q) = (%v, %q), want (%v, %q)", tt.key, got, layer, tt.wantVal, tt.wantLayer)
+			}
+		})
+	}
+
+	if _, _, err := r.Resolve("missing"); !errors.Is(err, ErrUnresolved) {
+		t.Errorf("Resolve(missing) error = %v, want ErrUnresolved", err)
+	}
+	if _, _, err := r.Resolve("outage"); !errors.Is(err, remoteErr) {
+		t.Errorf("Resolve(outage) error = %v, want remote error", err)
+	}
+	if _, _, err := r.Resolve("broken"); err == nil {
+		t.Error("Resolve(broken) accepted an invalid env value")
+	}
+}
+
+func TestResolver_Lookup(t *testing.T) {
+	r := NewResolver(MapSource("a", Map{"x": {}}), MapSource("b", Map{}))
+	v, layer, err := r.Lookup("x")
+	if err != nil || !v.IsNone() || layer != "" {
+		t.Errorf("Lookup(x) = (%v, %q, %v), want (None, \"\", nil)", v.value, layer, err)
+	}
+}
@@ -0,0 +1,70 @@
+package tristate
+
+import "errors"
+
+// TriResult is True, False, or Unknown with the error that made it
+// unknown, as in a health check that could not reach its dependency.
+// Aggregating with AllResults and AnyResults keeps the causes. The zero
+// value is Unknown with no cause.
+type TriResult struct {
+	value TriState
+	err   error
+}
+
+// Known returns the TriResult True or False.
+func Known(b bool) TriResult { return TriResult{value: New(b)} }
+
+// Unknown returns an Unknown TriResult caused by err, which may be nil.
+func Unknown(err error) TriResult { return TriResult{err: err} }
+
+// ResultOf returns Unknown(err) if err is non-nil and Known(b) otherwise,
+// to wrap calls returning (bool, error):
+//
+//	r := tristate.ResultOf(db.Ping(ctx))
+func ResultOf(b bool, err error) TriResult {
+	if err != nil {
+		return Unknown(err)
+	}
+	return Known(b)
+}
+
+// TriState returns r as a TriState, None for Unknown.
+func (r TriResult) TriState() TriState { return r.value }
+
+// IsUnknown reports whether r is Unknown.
+func (r TriResult) IsUnknown() bool { return r.value.IsNone() }
+
+// Err returns the cause of an Unknown result, or nil.
+func (r TriResult) Err() error { return r.err }
+
+// AllResults returns the Kleene conjunction of rs, as All does. An Unknown
+// result carries the causes of every Unknown input, joined. AllResults of
+// an empty list is True.
+func AllResults(rs ...TriResult) TriResult {
+	return combineResults(rs, False, Known(true))
+}
+
+// AnyResults returns the Kleene disjunction of rs, as Any does. An Unknown
+// result carries the causes of every Unknown input, joined. AnyResults of
+// an empty list is False.
+func AnyResults(rs ...TriResult) TriResult {
+	return combineResults(rs, True, Known(false))
+}
+
+func combineResults(rs []TriResult, absorbing State, empty TriResult) TriResult {
+	var unknown bool
+	var errs []error
+	for _, r := range rs {
+		switch r.value.value {
+		case absorbing:
+			return r
+		case None:
+			unknown = true
+			errs = append(errs, r.err)
+		}
+	}
+	if unknown {
+		return Unknown(errors.Join(errs...))
+	}
+	return empty
+}
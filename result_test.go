@@ -0,0 +1,60 @@
+package tristate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTriResult(t *testing.T) {
+	down := errors.New("db unreachable")
+	tests := []struct {
+		name    string
+		r       TriResult
+		want    TriState
+		wantErr error
+	}{
+		{"Known", Known(true), New(true), nil},
+		{"Unknown", Unknown(down), TriState{}, down},
+		{"Zero", TriResult{}, TriState{}, nil},
+		{"ResultOf ok", ResultOf(false, nil), New(false), nil},
+		{"ResultOf error", ResultOf(true, down), TriState{}, down},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.TriState(); got != tt.want {
+				t.Errorf("TriState() = %v, want %v", got, tt.want)
+			}
+			if got := tt.r.IsUnknown(); got != tt.want.IsNone() {
+				t.Errorf("IsUnknown() = %v", got)
+			}
+			if got := tt.r.Err(); got != tt.wantErr {
+				t.Errorf("Err() = %v, want %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllResults(t *testing.T) {
+	dbDown, cacheDown := errors.New("db down"), errors.New("cache down")
+
+	r := AllResults(Known(true), Unknown(dbDown), Unknown(cacheDown))
+	if !r.IsUnknown() || !errors.Is(r.Err(), dbDown) || !errors.Is(r.Err(), cacheDown) {
+		t.Errorf("AllResults() = %v, %v; want Unknown with both causes", r.TriState(), r.Err())
+	}
+	if r := AllResults(Unknown(dbDown), Known(false)); !r.TriState().IsFalse() || r.Err() != nil {
+		t.Errorf("AllResults() with a False = %v, %v", r.TriState(), r.Err())
+	}
+	if r := AllResults(); !r.TriState().IsTrue() {
+		t.Errorf("empty AllResults() = %v", r.TriState())
+	}
+
+	if r := AnyResults(Unknown(dbDown), Known(true)); !r.TriState().IsTrue() {
+		t.Errorf("AnyResults() with a True = %v", r.TriState())
+	}
+	if r := AnyResults(Known(false), Unknown(dbDown)); !r.IsUnknown() || !errors.Is(r.Err(), dbDown) {
+		t.Errorf("AnyResults() = %v, %v", r.TriState(), r.Err())
+	}
+	if r := AnyResults(Known(false)); !r.TriState().IsFalse() {
+		t.Errorf("AnyResults(false) = %v", r.TriState())
+	}
+}
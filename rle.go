@@ -0,0 +1,77 @@
+package tristate
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRLE is returned by DecodeRLE for malformed input.
+var ErrInvalidRLE = errors.New("tristate: invalid RLE data")
+
+// EncodeRLE run-length encodes s. Each run of identical values is written
+// as a single uvarint holding the run length shifted left two bits, with
+// the State in the low two bits. Vectors dominated by long None runs encode
+// to a few bytes regardless of their length; vectors that alternate often
+// are better served by the dense WriteTo encoding.
+func EncodeRLE(s *Slice) []byte {
+	var out []byte
+	for i := 0; i < s.n; {
+		v := s.Get(i).value
+		run := 1
+		i++
+		// Extend the run to a word boundary, then across whole words that
+		// hold nothing but v, then through the remaining elements.
+		pattern := uint64(v) * repeatPattern
+		for i%valuesPerWord != 0 && i < s.n && s.Get(i).value == v {
+			run++
+			i++
+		}
+		for i+valuesPerWord <= s.n && s.words[i/valuesPerWord] == pattern {
+			run += valuesPerWord
+			i += valuesPerWord
+		}
+		for i < s.n && s.Get(i).value == v {
+			run++
+			i++
+		}
+		out = binary.AppendUvarint(out, uint64(run)<<bitsPerValue|uint64(v))
+	}
+	return out
+}
+
+// MaxRLELen is the longest Slice DecodeRLE will produce. A few bytes of
+// RLE can declare an arbitrarily long run, so decoding untrusted input
+// must be bounded; use DecodeRLELimit to choose a different bound.
+const MaxRLELen = 1 << 26
+
+// DecodeRLE decodes data produced by EncodeRLE, rejecting input that
+// decodes to more than MaxRLELen elements.
+func DecodeRLE(data []byte) (Slice, error) {
+	return DecodeRLELimit(data, MaxRLELen)
+}
+
+// DecodeRLELimit decodes data produced by EncodeRLE, rejecting input that
+// decodes to more than maxLen elements before allocating for it.
+func DecodeRLELimit(data []byte, maxLen int) (Slice, error) {
+	var out Slice
+	for len(data) > 0 {
+		x, n := binary.Uvarint(data)
+		if n <= 0 {
+			return Slice{}, fmt.Errorf("%w: bad varint", ErrInvalidRLE)
+		}
+		data = data[n:]
+
+		v, run := State(x&valueMask), x>>bitsPerValue
+		if v > True || run == 0 {
+			return Slice{}, fmt.Errorf("%w: bad run %d of state %d", ErrInvalidRLE, run, v)
+		}
+		if run > uint64(max(maxLen-out.n, 0)) {
+			return Slice{}, fmt.Errorf("%w: run of %d exceeds limit of %d elements", ErrInvalidRLE, run, maxLen)
+		}
+		start := out.n
+		out.grow(int(run))
+		out.fillRange(start, out.n, TriState{value: v})
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestRLE_RoundTrip(t *testing.T) {
+	runs := NewSlice(10_000)
+	runs.Set(5000, New(true))
+	runs.fillRange(6000, 6100, New(false))
+
+	var alternating Slice
+	for i := 0; i < 97; i++ {
+		alternating.Append(TriState{value: State(i % 3)})
+	}
+
+	tests := []struct {
+		name  string
+		input Slice
+	}{
+		{"Empty", Slice{}},
+		{"Long runs", runs},
+		{"Alternating", alternating},
+		{"Single value", SliceOf(New(false))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := EncodeRLE(&tt.input)
+			got, err := DecodeRLE(data)
+			if err != nil {
+				t.Fatalf("DecodeRLE failed: %v", err)
+			}
+			if got.Len() != tt.input.Len() {
+				t.Fatalf("Len() = %d, want %d", got.Len(), tt.input.Len())
+			}
+			for i := 0; i < got.Len(); i++ {
+				if got.Get(i) != tt.input.Get(i) {
+					t.Fatalf("Get(%d) = %v, want %v", i, got.Get(i).value, tt.input.Get(i).value)
+				}
+			}
+		})
+	}
+
+	if data := EncodeRLE(&runs); len(data) > 12 {
+		t.Errorf("EncodeRLE of 5 runs took %d bytes", len(data))
+	}
+}
+
+func TestDecodeRLE_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"Truncated varint", []byte{0x80}},
+		{"Zero-length run", []byte{byte(True)}},
+		{"Invalid state", []byte{1<<bitsPerValue | 3}},
+		{"Huge run", binary.AppendUvarint(nil, 1<<40<<bitsPerValue|uint64(True))},
+		{"Runs over limit", binary.AppendUvarint(binary.AppendUvarint(nil, MaxRLELen<<bitsPerValue), 1<<bitsPerValue)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeRLE(tt.input); !errors.Is(err, ErrInvalidRLE) {
+				t.Errorf("DecodeRLE error = %v, want ErrInvalidRLE", err)
+			}
+		})
+	}
+}
+
+func TestDecodeRLELimit(t *testing.T) {
+	s := SliceOf(New(true), New(true), TriState{}, New(false))
+	data := EncodeRLE(&s)
+	if got, err := DecodeRLELimit(data, 4); err != nil || got.Len() != 4 {
+		t.Errorf("DecodeRLELimit(4) = %d elements, %v", got.Len(), err)
+	}
+	if _, err := DecodeRLELimit(data, 3); !errors.Is(err, ErrInvalidRLE) {
+		t.Errorf("DecodeRLELimit(3) error = %v, want ErrInvalidRLE", err)
+	}
+}
+
+// benchmarkVectors returns million-element vectors at a range of densities,
+// to compare RLE against the dense 2-bit snapshot encoding.
+func benchmarkVectors() []struct {
+	name string
+	s    Slice
+} {
+	rng := rand.New(rand.NewPCG(1, 2))
+	vector := func(density float64) Slice {
+		s := NewSlice(1 << 20)
+		for i := 0; i < s.Len(); i++ {
+			if rng.Float64() < density {
+				s.Set(i, New(rng.IntN(2) == 0))
+			}
+		}
+		return s
+	}
+	return []struct {
+		name string
+		s    Slice
+	}{
+		{"density=0.1%", vector(0.001)},
+		{"density=1%", vector(0.01)},
+		{"density=50%", vector(0.5)},
+	}
+}
+
+func BenchmarkEncodeRLE(b *testing.B) {
+	for _, bv := range benchmarkVectors() {
+		b.Run(bv.name, func(b *testing.B) {
+			var size int
+			for b.Loop() {
+				size = len(EncodeRLE(&bv.s))
+			}
+			b.ReportMetric(float64(size), "bytes")
+		})
+	}
+}
+
+func BenchmarkEncodeDense(b *testing.B) {
+	for _, bv := range benchmarkVectors() {
+		b.Run(bv.name, func(b *testing.B) {
+			var buf bytes.Buffer
+			for b.Loop() {
+				buf.Reset()
+				bv.s.WriteTo(&buf)
+			}
+			b.ReportMetric(float64(buf.Len()), "bytes")
+		})
+	}
+}
+
+func BenchmarkDecodeRLE(b *testing.B) {
+	for _, bv := range benchmarkVectors() {
+		data := EncodeRLE(&bv.s)
+		b.Run(bv.name, func(b *testing.B) {
+			for b.Loop() {
+				DecodeRLE(data)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package tristate
+
+import "encoding/json"
+
+// schemaDescription explains None in generated API documentation.
+const schemaDescription = "Tri-state boolean: true or false when set explicitly; null or absent when unset, in which case the value is inherited or defaulted."
+
+// Schema returns the OpenAPI 3.0 schema of a marshaled TriState:
+//
+//	{"type": "boolean", "nullable": true, "description": "..."}
+//
+// Each call returns a fresh map, so callers may adjust it. Reflection-based
+// generators see TriState as an empty object unless told otherwise; see
+// JSONSchemaBytes for swaggest and package tristateopenapi for kin-openapi.
+func (TriState) Schema() map[string]any {
+	return map[string]any{
+		"type":        "boolean",
+		"nullable":    true,
+		"description": schemaDescription,
+	}
+}
+
+// JSONSchemaBytes returns the JSON Schema of a marshaled TriState, with
+// None expressed as the "null" type. It implements the RawExposer
+// interface of swaggest/jsonschema-go, which swaggest/openapi-go converts
+// to the nullable boolean returned by Schema.
+func (TriState) JSONSchemaBytes() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type":        []string{"boolean", "null"},
+		"description": schemaDescription,
+	})
+}
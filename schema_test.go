@@ -0,0 +1,33 @@
+package tristate
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestTriState_Schema(t *testing.T) {
+	s := TriState{}.Schema()
+	if s["type"] != "boolean" || s["nullable"] != true || s["description"] != schemaDescription {
+		t.Errorf("Schema() = %v", s)
+	}
+	s["type"] = "string"
+	if (TriState{}).Schema()["type"] != "boolean" {
+		t.Error("Schema() returned a shared map")
+	}
+
+	data, err := TriState{}.JSONSchemaBytes()
+	if err != nil {
+		t.Fatalf("JSONSchemaBytes failed: %v", err)
+	}
+	var js struct {
+		Type        []string `json:"type"`
+		Description string   `json:"description"`
+	}
+	if err := json.Unmarshal(data, &js); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !slices.Equal(js.Type, []string{"boolean", "null"}) || js.Description != schemaDescription {
+		t.Errorf("JSONSchemaBytes() = %s", data)
+	}
+}
@@ -0,0 +1,61 @@
+package tristate
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Lift converts a sequence of bools to a sequence of explicit TriStates.
+func Lift(seq iter.Seq[bool]) iter.Seq[TriState] {
+	return func(yield func(TriState) bool) {
+		for b := range seq {
+			if !yield(New(b)) {
+				return
+			}
+		}
+	}
+}
+
+// BoolsOr converts a sequence of TriStates to bools, replacing None with
+// defaultVal as ValueOr does.
+func BoolsOr(seq iter.Seq[TriState], defaultVal bool) iter.Seq[bool] {
+	return func(yield func(bool) bool) {
+		for v := range seq {
+			if !yield(v.ValueOr(defaultVal)) {
+				return
+			}
+		}
+	}
+}
+
+// BoolsSkipNone converts a sequence of TriStates to bools, dropping None
+// values.
+func BoolsSkipNone(seq iter.Seq[TriState]) iter.Seq[bool] {
+	return func(yield func(bool) bool) {
+		for v := range seq {
+			if b, ok := v.Bool(); ok && !yield(b) {
+				return
+			}
+		}
+	}
+}
+
+// BoolsStrict converts a sequence of TriStates to bools, treating None as
+// an error: at the first None it yields false with an error wrapping
+// ErrRequired and naming the position, then stops.
+func BoolsStrict(seq iter.Seq[TriState]) iter.Seq2[bool, error] {
+	return func(yield func(bool, error) bool) {
+		i := 0
+		for v := range seq {
+			b, ok := v.Bool()
+			if !ok {
+				yield(false, fmt.Errorf("%w: element %d is none", ErrRequired, i))
+				return
+			}
+			if !yield(b, nil) {
+				return
+			}
+			i++
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package tristate
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLift(t *testing.T) {
+	got := slices.Collect(Lift(slices.Values([]bool{true, false})))
+	if want := []TriState{New(true), New(false)}; !slices.Equal(got, want) {
+		t.Errorf("Lift() = %v, want %v", got, want)
+	}
+}
+
+func TestBools(t *testing.T) {
+	vals := slices.Values([]TriState{New(true), {}, New(false)})
+	if got, want := slices.Collect(BoolsOr(vals, true)), []bool{true, true, false}; !slices.Equal(got, want) {
+		t.Errorf("BoolsOr() = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(BoolsSkipNone(vals)), []bool{true, false}; !slices.Equal(got, want) {
+		t.Errorf("BoolsSkipNone() = %v, want %v", got, want)
+	}
+
+	var got []bool
+	var err error
+	for b, e := range BoolsStrict(vals) {
+		if e != nil {
+			err = e
+			continue
+		}
+		got = append(got, b)
+	}
+	if !slices.Equal(got, []bool{true}) || !errors.Is(err, ErrRequired) || err.Error() != "tristate: value required: element 1 is none" {
+		t.Errorf("BoolsStrict() = %v, %v", got, err)
+	}
+
+	// Consumers may stop early.
+	for range BoolsOr(vals, false) {
+		break
+	}
+	for range BoolsStrict(vals) {
+		break
+	}
+}
@@ -0,0 +1,276 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// Packing layout: each State occupies bitsPerValue bits of a uint64 word.
+const (
+	bitsPerValue  = 2
+	valuesPerWord = 64 / bitsPerValue
+	valueMask     = 1<<bitsPerValue - 1
+
+	// repeatPattern multiplied by a State yields a word holding that State
+	// in every slot.
+	repeatPattern = 0x5555555555555555
+
+	// lowBits selects the low bit of every slot in a word.
+	lowBits = repeatPattern
+)
+
+// Slice is a growable sequence of TriState values packed 2 bits per element
+// into a []uint64, using a quarter of the memory of an equivalent []TriState.
+// The zero value is an empty Slice ready to use.
+//
+// Like a built-in slice, copies of a Slice share the same backing storage.
+type Slice struct {
+	words []uint64
+	n     int
+}
+
+// --- Factory Methods ---
+
+// NewSlice returns a Slice of length n with every element set to None.
+func NewSlice(n int) Slice {
+	if n < 0 {
+		panic("tristate: negative Slice length")
+	}
+	return Slice{words: make([]uint64, wordsFor(n)), n: n}
+}
+
+// SliceOf returns a packed Slice holding the given values.
+func SliceOf(vals ...TriState) Slice {
+	s := NewSlice(len(vals))
+	for i, v := range vals {
+		s.Set(i, v)
+	}
+	return s
+}
+
+// --- Accessors ---
+
+// Len returns the number of elements in the Slice.
+func (s *Slice) Len() int { return s.n }
+
+// Get returns the element at index i. It panics if i is out of range.
+func (s *Slice) Get(i int) TriState {
+	s.checkIndex(i)
+	w, shift := i/valuesPerWord, uint(i%valuesPerWord)*bitsPerValue
+	return TriState{value: State(s.words[w] >> shift & valueMask)}
+}
+
+// Set stores v at index i. It panics if i is out of range.
+func (s *Slice) Set(i int, v TriState) {
+	s.checkIndex(i)
+	w, shift := i/valuesPerWord, uint(i%valuesPerWord)*bitsPerValue
+	s.words[w] = s.words[w]&^(valueMask<<shift) | uint64(v.value)<<shift
+}
+
+// Append adds the given values to the end of the Slice, growing the backing
+// storage as needed. Appends run in amortized O(1) time per element.
+func (s *Slice) Append(vals ...TriState) {
+	for _, v := range vals {
+		if s.n == len(s.words)*valuesPerWord {
+			s.words = append(s.words, 0)
+		}
+		s.n++
+		s.Set(s.n-1, v)
+	}
+}
+
+// Counts returns how many elements are True, False, and None. It tallies a
+// whole word at a time rather than element by element.
+func (s *Slice) Counts() (trueN, falseN, noneN int) {
+	trueN, falseN = countWords(s.words)
+	return trueN, falseN, s.n - trueN - falseN
+}
+
+// countWords tallies the True and False slots across packed words.
+func countWords(words []uint64) (trueN, falseN int) {
+	for _, w := range words {
+		trueN += bits.OnesCount64(w >> 1 & lowBits)
+		falseN += bits.OnesCount64(w & lowBits)
+	}
+	return trueN, falseN
+}
+
+// --- Iteration ---
+
+// All returns an iterator over the index-value pairs of the Slice.
+func (s *Slice) All() iter.Seq2[int, TriState] {
+	return func(yield func(int, TriState) bool) {
+		for i := 0; i < s.n; i++ {
+			if !yield(i, s.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the Slice.
+func (s *Slice) Values() iter.Seq[TriState] {
+	return func(yield func(TriState) bool) {
+		for i := 0; i < s.n; i++ {
+			if !yield(s.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSlice packs the values of seq into a new Slice.
+func CollectSlice(seq iter.Seq[TriState]) Slice {
+	var s Slice
+	for v := range seq {
+		s.Append(v)
+	}
+	return s
+}
+
+// grow extends s by n None elements.
+func (s *Slice) grow(n int) {
+	s.n += n
+	if need := wordsFor(s.n); need > len(s.words) {
+		s.words = append(s.words, make([]uint64, need-len(s.words))...)
+	}
+}
+
+// fillRange sets elements [start, end) to v, writing whole words where the
+// range covers them.
+func (s *Slice) fillRange(start, end int, v TriState) {
+	pattern := uint64(v.value) * repeatPattern
+	i := start
+	for ; i < end && i%valuesPerWord != 0; i++ {
+		s.Set(i, v)
+	}
+	for ; i+valuesPerWord <= end; i += valuesPerWord {
+		s.words[i/valuesPerWord] = pattern
+	}
+	for ; i < end; i++ {
+		s.Set(i, v)
+	}
+}
+
+func (s *Slice) checkIndex(i int) {
+	if i < 0 || i >= s.n {
+		panic("tristate: Slice index out of range")
+	}
+}
+
+// wordsFor returns the number of uint64 words needed to hold n values.
+func wordsFor(n int) int {
+	return (n + valuesPerWord - 1) / valuesPerWord
+}
+
+// --- JSON Marshaling ---
+
+// Compact string alphabet used by CompactSlice.
+const (
+	compactTrue  = 'T'
+	compactFalse = 'F'
+	compactNone  = '-'
+)
+
+// MarshalJSON encodes the Slice as a JSON array of true, false, and null.
+func (s Slice) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 2+s.n*6)
+	buf = append(buf, '[')
+	for i := 0; i < s.n; i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		b, _ := s.Get(i).MarshalJSON()
+		buf = append(buf, b...)
+	}
+	return append(buf, ']'), nil
+}
+
+// UnmarshalJSON decodes either a JSON array of true/false/null or the
+// compact string form produced by CompactSlice. A JSON null leaves the
+// Slice empty.
+func (s *Slice) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*s = Slice{}
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		return s.decodeCompact(str)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return fmt.Errorf("invalid tristate slice: %s", string(data))
+	}
+	var out Slice
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case true:
+			out.Append(New(true))
+		case false:
+			out.Append(New(false))
+		case nil:
+			out.Append(TriState{})
+		default:
+			return fmt.Errorf("invalid tristate value: %v", tok)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	*s = out
+	return nil
+}
+
+func (s *Slice) decodeCompact(str string) error {
+	out := NewSlice(len(str))
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case compactTrue:
+			out.Set(i, New(true))
+		case compactFalse:
+			out.Set(i, New(false))
+		case compactNone:
+		default:
+			return fmt.Errorf("invalid compact tristate value %q at index %d", str[i], i)
+		}
+	}
+	*s = out
+	return nil
+}
+
+// CompactSlice is a Slice that marshals to a compact JSON string with one
+// character per element: 'T' for True, 'F' for False, and '-' for None.
+// Slice.UnmarshalJSON accepts this form as well, so the two interoperate.
+type CompactSlice struct {
+	Slice
+}
+
+// MarshalJSON encodes the Slice as a compact string such as "TF-T".
+func (c CompactSlice) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 2+c.n)
+	buf = append(buf, '"')
+	for i := 0; i < c.n; i++ {
+		switch c.Get(i).value {
+		case True:
+			buf = append(buf, compactTrue)
+		case False:
+			buf = append(buf, compactFalse)
+		default:
+			buf = append(buf, compactNone)
+		}
+	}
+	return append(buf, '"'), nil
+}
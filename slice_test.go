@@ -0,0 +1,232 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSlice_GetSet(t *testing.T) {
+	s := NewSlice(100)
+	if s.Len() != 100 {
+		t.Fatalf("Len() = %d, want 100", s.Len())
+	}
+	for i := 0; i < s.Len(); i++ {
+		if !s.Get(i).IsNone() {
+			t.Fatalf("Get(%d) = %v, want None", i, s.Get(i).value)
+		}
+	}
+
+	states := []TriState{New(true), New(false), {}}
+	for i := 0; i < s.Len(); i++ {
+		s.Set(i, states[i%3])
+	}
+	// Overwrite a few values to make sure neighbouring bits are untouched.
+	s.Set(31, New(true))
+	s.Set(32, New(false))
+
+	for i := 0; i < s.Len(); i++ {
+		want := states[i%3]
+		switch i {
+		case 31:
+			want = New(true)
+		case 32:
+			want = New(false)
+		}
+		if got := s.Get(i); got != want {
+			t.Errorf("Get(%d) = %v, want %v", i, got.value, want.value)
+		}
+	}
+}
+
+func TestSlice_Append(t *testing.T) {
+	var s Slice
+	var want []TriState
+	for i := 0; i < 1000; i++ {
+		v := TriState{value: State(i % 3)}
+		s.Append(v)
+		want = append(want, v)
+	}
+	if s.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", s.Len(), len(want))
+	}
+	for i, v := range want {
+		if got := s.Get(i); got != v {
+			t.Fatalf("Get(%d) = %v, want %v", i, got.value, v.value)
+		}
+	}
+	if got, wantWords := len(s.words), wordsFor(len(want)); got != wantWords {
+		t.Errorf("backing words = %d, want %d", got, wantWords)
+	}
+}
+
+func TestSliceOf(t *testing.T) {
+	s := SliceOf(New(true), TriState{}, New(false))
+	tests := []struct {
+		index int
+		want  State
+	}{
+		{0, True},
+		{1, None},
+		{2, False},
+	}
+	for _, tt := range tests {
+		if got := s.Get(tt.index).value; got != tt.want {
+			t.Errorf("Get(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestSlice_IndexOutOfRange(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(s *Slice)
+	}{
+		{"Get past end", func(s *Slice) { s.Get(3) }},
+		{"Get negative", func(s *Slice) { s.Get(-1) }},
+		{"Set past end", func(s *Slice) { s.Set(3, New(true)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			s := NewSlice(3)
+			tt.fn(&s)
+		})
+	}
+}
+
+func TestSlice_JSON(t *testing.T) {
+	s := SliceOf(New(true), New(false), TriState{}, New(true))
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `[true,false,null,true]`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	compact, err := json.Marshal(CompactSlice{s})
+	if err != nil {
+		t.Fatalf("Marshal compact failed: %v", err)
+	}
+	if want := `"TF-T"`; string(compact) != want {
+		t.Errorf("Marshal compact = %s, want %s", compact, want)
+	}
+
+	tests := []struct {
+		name   string
+		jsonIn string
+	}{
+		{"Array", string(data)},
+		{"Array with whitespace", "[ true, false,\n null , true ]"},
+		{"Compact string", string(compact)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Slice
+			if err := json.Unmarshal([]byte(tt.jsonIn), &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if got.Len() != s.Len() {
+				t.Fatalf("Len() = %d, want %d", got.Len(), s.Len())
+			}
+			for i := 0; i < s.Len(); i++ {
+				if got.Get(i) != s.Get(i) {
+					t.Errorf("Get(%d) = %v, want %v", i, got.Get(i).value, s.Get(i).value)
+				}
+			}
+		})
+	}
+}
+
+func TestSlice_UnmarshalJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		jsonIn string
+	}{
+		{"Number element", `[true, 1]`},
+		{"String element", `["true"]`},
+		{"Object", `{}`},
+		{"Bad compact character", `"TFx"`},
+		{"Unterminated array", `[true,`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Slice
+			if err := json.Unmarshal([]byte(tt.jsonIn), &s); err == nil {
+				t.Errorf("Unmarshal(%s) succeeded, want error", tt.jsonIn)
+			}
+		})
+	}
+}
+
+func TestCompactSlice_Field(t *testing.T) {
+	type Container struct {
+		Flags CompactSlice `json:"flags"`
+	}
+	var c Container
+	if err := json.Unmarshal([]byte(`{"flags":"-T"}`), &c); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if c.Flags.Len() != 2 || !c.Flags.Get(0).IsNone() || !c.Flags.Get(1).IsTrue() {
+		t.Errorf("unexpected decoded flags")
+	}
+	data, _ := json.Marshal(c)
+	if want := `{"flags":"-T"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+}
+
+func TestSlice_Iterators(t *testing.T) {
+	s := SliceOf(New(true), TriState{}, New(false))
+
+	var idx []int
+	for i, v := range s.All() {
+		if v != s.Get(i) {
+			t.Errorf("All() yielded %v at %d, want %v", v.value, i, s.Get(i).value)
+		}
+		idx = append(idx, i)
+	}
+	if len(idx) != 3 || idx[2] != 2 {
+		t.Errorf("All() yielded indexes %v, want [0 1 2]", idx)
+	}
+
+	for range s.All() {
+		break // must not panic when the loop exits early
+	}
+
+	got := CollectSlice(s.Values())
+	if got.Len() != s.Len() {
+		t.Fatalf("CollectSlice Len() = %d, want %d", got.Len(), s.Len())
+	}
+	for i := 0; i < s.Len(); i++ {
+		if got.Get(i) != s.Get(i) {
+			t.Errorf("CollectSlice Get(%d) = %v, want %v", i, got.Get(i).value, s.Get(i).value)
+		}
+	}
+}
+
+func TestSlice_Counts(t *testing.T) {
+	var s Slice
+	var vals []TriState
+	for i := 0; i < 1001; i++ {
+		v := TriState{value: State(i * 7 % 3)}
+		s.Append(v)
+		vals = append(vals, v)
+	}
+	wantT, wantF, wantN := Count(vals)
+	gotT, gotF, gotN := s.Counts()
+	if gotT != wantT || gotF != wantF || gotN != wantN {
+		t.Errorf("Counts() = (%d, %d, %d), want (%d, %d, %d)", gotT, gotF, gotN, wantT, wantF, wantN)
+	}
+
+	s = NewSlice(70)
+	s.Set(69, New(true))
+	if gotT, gotF, gotN := s.Counts(); gotT != 1 || gotF != 0 || gotN != 69 {
+		t.Errorf("Counts() = (%d, %d, %d), want (1, 0, 69)", gotT, gotF, gotN)
+	}
+}
@@ -0,0 +1,105 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Snapshot format: a 16-byte header followed by the packed words, all
+// little-endian.
+//
+//	magic   [4]byte  "TSS1"
+//	version uint32   snapshotVersion
+//	length  uint64   number of elements
+//	words   []uint64 ceil(length/32) packed words
+var snapshotMagic = [4]byte{'T', 'S', 'S', '1'}
+
+const (
+	snapshotVersion    = 1
+	snapshotHeaderSize = 16
+
+	// maxSnapshotLen bounds the length accepted from a snapshot header. It
+	// also keeps the length, and the word count rounded up from it, within
+	// int on 32-bit platforms.
+	maxSnapshotLen = min(1<<40, math.MaxInt-valuesPerWord)
+
+	// snapshotChunkWords is how many words ReadFrom reads at a time. The
+	// words are allocated as they arrive, so a header claiming a huge
+	// length with a short body fails without a huge allocation.
+	snapshotChunkWords = 4096
+)
+
+// ErrInvalidSnapshot is returned by ReadFrom when the input is not a Slice
+// snapshot or was written by an unsupported version.
+var ErrInvalidSnapshot = errors.New("tristate: invalid Slice snapshot")
+
+// WriteTo writes a binary snapshot of s to w. It implements io.WriterTo.
+func (s *Slice) WriteTo(w io.Writer) (int64, error) {
+	var header [snapshotHeaderSize]byte
+	copy(header[:4], snapshotMagic[:])
+	binary.LittleEndian.PutUint32(header[4:8], snapshotVersion)
+	binary.LittleEndian.PutUint64(header[8:16], uint64(s.n))
+
+	n, err := w.Write(header[:])
+	written := int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	buf := make([]byte, 0, 8*wordsFor(s.n))
+	for _, word := range s.words[:wordsFor(s.n)] {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+	}
+	n, err = w.Write(buf)
+	return written + int64(n), err
+}
+
+// ReadFrom replaces the contents of s with a snapshot read from r. It
+// implements io.ReaderFrom. On error s is left unchanged.
+func (s *Slice) ReadFrom(r io.Reader) (int64, error) {
+	var header [snapshotHeaderSize]byte
+	n, err := io.ReadFull(r, header[:])
+	read := int64(n)
+	if err != nil {
+		return read, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if !bytes.Equal(header[:4], snapshotMagic[:]) {
+		return read, fmt.Errorf("%w: bad magic %q", ErrInvalidSnapshot, header[:4])
+	}
+	if v := binary.LittleEndian.Uint32(header[4:8]); v != snapshotVersion {
+		return read, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, v)
+	}
+	length := binary.LittleEndian.Uint64(header[8:16])
+	if length > uint64(maxSnapshotLen) {
+		return read, fmt.Errorf("%w: length %d too large", ErrInvalidSnapshot, length)
+	}
+
+	total := wordsFor(int(length))
+	words := make([]uint64, 0, min(total, snapshotChunkWords))
+	buf := make([]byte, 8*min(total, snapshotChunkWords))
+	for len(words) < total {
+		chunk := buf[:8*min(total-len(words), snapshotChunkWords)]
+		n, err := io.ReadFull(r, chunk)
+		read += int64(n)
+		if err != nil {
+			return read, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+		}
+		for off := 0; off < len(chunk); off += 8 {
+			word := binary.LittleEndian.Uint64(chunk[off:])
+			if word>>1&word&lowBits != 0 {
+				return read, fmt.Errorf("%w: corrupt word %d", ErrInvalidSnapshot, len(words))
+			}
+			words = append(words, word)
+		}
+	}
+	out := Slice{words: words, n: int(length)}
+	if tail := out.n % valuesPerWord; tail != 0 && out.words[len(out.words)-1]>>(tail*bitsPerValue) != 0 {
+		return read, fmt.Errorf("%w: nonzero padding", ErrInvalidSnapshot)
+	}
+	*s = out
+	return read, nil
+}
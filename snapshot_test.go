@@ -0,0 +1,96 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestSlice_Snapshot(t *testing.T) {
+	var s Slice
+	for i := 0; i < 100; i++ {
+		s.Append(TriState{value: State(i % 3)})
+	}
+
+	var buf bytes.Buffer
+	n, err := s.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if want := int64(snapshotHeaderSize + 8*wordsFor(100)); n != want || int64(buf.Len()) != want {
+		t.Errorf("WriteTo wrote %d bytes (buffer %d), want %d", n, buf.Len(), want)
+	}
+
+	var got Slice
+	m, err := got.ReadFrom(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if m != n {
+		t.Errorf("ReadFrom read %d bytes, want %d", m, n)
+	}
+	if got.Len() != s.Len() {
+		t.Fatalf("Len() = %d, want %d", got.Len(), s.Len())
+	}
+	for i := 0; i < s.Len(); i++ {
+		if got.Get(i) != s.Get(i) {
+			t.Fatalf("Get(%d) = %v, want %v", i, got.Get(i).value, s.Get(i).value)
+		}
+	}
+}
+
+func TestSlice_ReadFromInvalid(t *testing.T) {
+	s := SliceOf(New(true), New(false))
+	var buf bytes.Buffer
+	s.WriteTo(&buf)
+	valid := buf.Bytes()
+
+	corrupt := func(edit func(b []byte)) []byte {
+		b := bytes.Clone(valid)
+		edit(b)
+		return b
+	}
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"Empty", nil},
+		{"Bad magic", corrupt(func(b []byte) { b[0] = 'X' })},
+		{"Future version", corrupt(func(b []byte) { b[4] = 9 })},
+		{"Truncated words", valid[:len(valid)-1]},
+		{"Invalid state bits", corrupt(func(b []byte) { b[snapshotHeaderSize] = 0b11 })},
+		{"Nonzero padding", corrupt(func(b []byte) { b[len(b)-1] = 1 })},
+		{"Huge length, truncated body", corrupt(func(b []byte) { binary.LittleEndian.PutUint64(b[8:16], maxSnapshotLen) })},
+		{"Length over limit", corrupt(func(b []byte) { binary.LittleEndian.PutUint64(b[8:16], maxSnapshotLen+1) })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SliceOf(TriState{})
+			_, err := got.ReadFrom(bytes.NewReader(tt.input))
+			if !errors.Is(err, ErrInvalidSnapshot) {
+				t.Errorf("ReadFrom error = %v, want ErrInvalidSnapshot", err)
+			}
+			if got.Len() != 1 {
+				t.Error("ReadFrom modified the Slice on error")
+			}
+		})
+	}
+}
+
+func TestSlice_SnapshotChunks(t *testing.T) {
+	n := snapshotChunkWords*valuesPerWord + 5
+	s := NewSlice(n)
+	s.Set(0, New(true))
+	s.Set(n-1, New(false))
+
+	var buf bytes.Buffer
+	s.WriteTo(&buf)
+	var got Slice
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if got.Len() != n || !got.Get(0).IsTrue() || !got.Get(n-1).IsFalse() || !got.Get(n/2).IsNone() {
+		t.Error("ReadFrom across chunks did not round-trip")
+	}
+}
@@ -0,0 +1,179 @@
+package tristate
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Vector is the interface shared by the dense Slice and the SparseSlice, so
+// callers can switch representation without changing call sites.
+type Vector interface {
+	Len() int
+	Get(i int) TriState
+	Set(i int, v TriState)
+	Append(vals ...TriState)
+	Counts() (trueN, falseN, noneN int)
+	All() iter.Seq2[int, TriState]
+	Values() iter.Seq[TriState]
+}
+
+var (
+	_ Vector = (*Slice)(nil)
+	_ Vector = (*SparseSlice)(nil)
+)
+
+// sparseEntryBytes approximates the memory a SparseSlice spends per
+// explicit value, including map overhead.
+const sparseEntryBytes = 24
+
+// SparseSlice is a sequence of TriState values that stores only the
+// explicitly set indices. It beats the dense Slice when well under 1% of
+// elements are set. The zero value is an empty SparseSlice ready to use.
+type SparseSlice struct {
+	set map[int]State
+	n   int
+}
+
+// --- Factory Methods ---
+
+// NewSparseSlice returns a SparseSlice of length n with every element None.
+func NewSparseSlice(n int) SparseSlice {
+	if n < 0 {
+		panic("tristate: negative Slice length")
+	}
+	return SparseSlice{n: n}
+}
+
+// --- Accessors ---
+
+// Len returns the number of elements in the SparseSlice.
+func (s *SparseSlice) Len() int { return s.n }
+
+// Get returns the element at index i. It panics if i is out of range.
+func (s *SparseSlice) Get(i int) TriState {
+	s.checkIndex(i)
+	return TriState{value: s.set[i]}
+}
+
+// Set stores v at index i. Setting None releases the index's storage. It
+// panics if i is out of range.
+func (s *SparseSlice) Set(i int, v TriState) {
+	s.checkIndex(i)
+	if v.IsNone() {
+		delete(s.set, i)
+		return
+	}
+	if s.set == nil {
+		s.set = make(map[int]State)
+	}
+	s.set[i] = v.value
+}
+
+// Append adds the given values to the end of the SparseSlice.
+func (s *SparseSlice) Append(vals ...TriState) {
+	for _, v := range vals {
+		s.n++
+		s.Set(s.n-1, v)
+	}
+}
+
+// Counts returns how many elements are True, False, and None.
+func (s *SparseSlice) Counts() (trueN, falseN, noneN int) {
+	for _, v := range s.set {
+		if v == True {
+			trueN++
+		} else {
+			falseN++
+		}
+	}
+	return trueN, falseN, s.n - trueN - falseN
+}
+
+// --- Iteration ---
+
+// All returns an iterator over the index-value pairs of the SparseSlice,
+// including None elements, in index order.
+func (s *SparseSlice) All() iter.Seq2[int, TriState] {
+	return func(yield func(int, TriState) bool) {
+		for i := 0; i < s.n; i++ {
+			if !yield(i, TriState{value: s.set[i]}) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the SparseSlice, including
+// None elements, in index order.
+func (s *SparseSlice) Values() iter.Seq[TriState] {
+	return func(yield func(TriState) bool) {
+		for _, v := range s.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Explicit returns an iterator over only the explicitly set elements, in
+// index order.
+func (s *SparseSlice) Explicit() iter.Seq2[int, TriState] {
+	return func(yield func(int, TriState) bool) {
+		for _, i := range slices.Sorted(maps.Keys(s.set)) {
+			if !yield(i, TriState{value: s.set[i]}) {
+				return
+			}
+		}
+	}
+}
+
+func (s *SparseSlice) checkIndex(i int) {
+	if i < 0 || i >= s.n {
+		panic("tristate: Slice index out of range")
+	}
+}
+
+// --- Conversion ---
+
+// Sparse returns a SparseSlice holding the same values as s.
+func (s *Slice) Sparse() SparseSlice {
+	out := NewSparseSlice(s.n)
+	for i := 0; i < s.n; i++ {
+		if v := s.Get(i); !v.IsNone() {
+			out.Set(i, v)
+		}
+	}
+	return out
+}
+
+// Dense returns a packed Slice holding the same values as s.
+func (s *SparseSlice) Dense() Slice {
+	out := NewSlice(s.n)
+	for i, v := range s.set {
+		out.Set(i, TriState{value: v})
+	}
+	return out
+}
+
+// Optimize returns v in whichever representation needs less memory for its
+// current density: a *SparseSlice when few elements are explicitly set, a
+// *Slice otherwise. v itself is returned if it is already the better fit.
+func Optimize(v Vector) Vector {
+	trueN, falseN, _ := v.Counts()
+	sparse := (trueN+falseN)*sparseEntryBytes < wordsFor(v.Len())*8
+
+	switch v := v.(type) {
+	case *Slice:
+		if sparse {
+			out := v.Sparse()
+			return &out
+		}
+	case *SparseSlice:
+		if !sparse {
+			out := v.Dense()
+			return &out
+		}
+	}
+	return v
+}
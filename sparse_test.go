@@ -0,0 +1,94 @@
+package tristate
+
+import "testing"
+
+func TestSparseSlice_GetSet(t *testing.T) {
+	s := NewSparseSlice(1000)
+	s.Set(10, New(true))
+	s.Set(500, New(false))
+	s.Set(999, New(true))
+	s.Set(999, TriState{})
+
+	tests := []struct {
+		index int
+		want  State
+	}{
+		{0, None},
+		{10, True},
+		{500, False},
+		{999, None},
+	}
+	for _, tt := range tests {
+		if got := s.Get(tt.index).value; got != tt.want {
+			t.Errorf("Get(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+	if len(s.set) != 2 {
+		t.Errorf("stored %d entries, want 2", len(s.set))
+	}
+	if gotT, gotF, gotN := s.Counts(); gotT != 1 || gotF != 1 || gotN != 998 {
+		t.Errorf("Counts() = (%d, %d, %d), want (1, 1, 998)", gotT, gotF, gotN)
+	}
+}
+
+func TestSparseSlice_AppendAndIterate(t *testing.T) {
+	var s SparseSlice
+	s.Append(TriState{}, New(false), TriState{}, New(true))
+	if s.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", s.Len())
+	}
+
+	var all []State
+	for _, v := range s.All() {
+		all = append(all, v.value)
+	}
+	if want := []State{None, False, None, True}; len(all) != 4 || all[1] != want[1] || all[3] != want[3] {
+		t.Errorf("All() yielded %v, want %v", all, want)
+	}
+
+	var idx []int
+	for i := range s.Explicit() {
+		idx = append(idx, i)
+	}
+	if len(idx) != 2 || idx[0] != 1 || idx[1] != 3 {
+		t.Errorf("Explicit() yielded indexes %v, want [1 3]", idx)
+	}
+}
+
+func TestSparseSlice_Conversion(t *testing.T) {
+	dense := NewSlice(200)
+	dense.Set(3, New(true))
+	dense.Set(150, New(false))
+
+	sparse := dense.Sparse()
+	back := sparse.Dense()
+	for i := 0; i < dense.Len(); i++ {
+		if sparse.Get(i) != dense.Get(i) || back.Get(i) != dense.Get(i) {
+			t.Fatalf("conversion changed element %d", i)
+		}
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	mostlyNone := NewSlice(100_000)
+	mostlyNone.Set(7, New(true))
+	if _, ok := Optimize(&mostlyNone).(*SparseSlice); !ok {
+		t.Error("Optimize kept a mostly-None Slice dense")
+	}
+
+	var busy SparseSlice
+	for i := 0; i < 1000; i++ {
+		busy.Append(New(i%2 == 0))
+	}
+	got, ok := Optimize(&busy).(*Slice)
+	if !ok {
+		t.Fatal("Optimize kept a fully set SparseSlice sparse")
+	}
+	if !got.Get(0).IsTrue() || !got.Get(1).IsFalse() {
+		t.Error("Optimize changed values during conversion")
+	}
+
+	if Optimize(got) != Vector(got) {
+		t.Error("Optimize converted a Slice that was already the better fit")
+	}
+}
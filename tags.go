@@ -0,0 +1,70 @@
+package tristate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tagName is the struct tag key read by ApplyDefaults and ValidateStruct.
+// Options are comma-separated, e.g. `tristate:"required,default=true"`.
+const tagName = "tristate"
+
+// tagOptions holds the parsed options of a tristate struct tag.
+type tagOptions struct {
+	defaultValue TriState
+	hasDefault   bool
+	required     bool
+}
+
+func parseTag(tag string) (tagOptions, error) {
+	var opts tagOptions
+	for _, opt := range strings.Split(tag, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch key {
+		case "":
+		case "default":
+			v, err := Parse(val)
+			if err != nil {
+				return opts, fmt.Errorf("bad default: %w", err)
+			}
+			opts.defaultValue, opts.hasDefault = v, true
+		case "required":
+			opts.required = true
+		default:
+			return opts, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return opts, nil
+}
+
+// walkFields calls fn for every settable TriState or Traced field reachable
+// from the struct v, descending into nested structs, embedded structs, and
+// non-nil struct pointers. path is the dotted Go field path of the field.
+func walkFields(v reflect.Value, path string, fn func(field reflect.Value, sf reflect.StructField, path string) error) error {
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		fv, fpath := v.Field(i), path+sf.Name
+		switch fv.Type() {
+		case triStateType, tracedType:
+			if fv.CanSet() {
+				if err := fn(fv, sf, fpath); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct {
+			if err := walkFields(fv, fpath+".", fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
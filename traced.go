@@ -0,0 +1,47 @@
+package tristate
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Traced is a TriState annotated with where its value came from, so an
+// effective setting can be explained after layers have been merged.
+type Traced struct {
+	Value  TriState  `json:"value"`
+	Source string    `json:"source,omitempty"` // Layer that set the value, e.g. "env"
+	Time   time.Time `json:"time,omitzero"`    // When the value was set
+	Actor  string    `json:"actor,omitempty"`  // Optional user or system that set it
+}
+
+// Trace returns v attributed to source and stamped with the current time.
+func Trace(v TriState, source string) Traced {
+	return Traced{Value: v, Source: source, Time: time.Now()}
+}
+
+// Override returns src if its value is set, otherwise t. The winning value
+// keeps its own provenance, mirroring Map.MergeOverride.
+func (t Traced) Override(src Traced) Traced {
+	if src.Value.IsNone() {
+		return t
+	}
+	return src
+}
+
+// UnmarshalJSON accepts the object form that encoding/json produces from
+// the struct tags, such as {"value":true,"source":"env"}, as well as a bare
+// true, false, or null, which decodes with no provenance.
+func (t *Traced) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' {
+		*t = Traced{}
+		return t.Value.UnmarshalJSON(trimmed)
+	}
+	type plain Traced
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*t = Traced(p)
+	return nil
+}
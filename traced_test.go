@@ -0,0 +1,79 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTraced_Override(t *testing.T) {
+	base := Traced{Value: New(true), Source: "default"}
+	env := Traced{Value: New(false), Source: "env", Actor: "deploy-bot"}
+
+	if got := base.Override(env); got != env {
+		t.Errorf("Override(set) = %+v, want %+v", got, env)
+	}
+	if got := base.Override(Traced{Source: "file"}); got != base {
+		t.Errorf("Override(None) = %+v, want %+v", got, base)
+	}
+}
+
+func TestTraced_JSON(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tr := Traced{Value: New(false), Source: "admin", Time: at, Actor: "alice"}
+
+	data, err := json.Marshal(tr)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"value":false,"source":"admin","time":"2024-05-01T12:00:00Z","actor":"alice"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	tests := []struct {
+		name   string
+		jsonIn string
+		want   Traced
+	}{
+		{"Extended object", want, tr},
+		{"Bare bool", `true`, Traced{Value: New(true)}},
+		{"Bare null", `null`, Traced{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Traced
+			if err := json.Unmarshal([]byte(tt.jsonIn), &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !got.Time.Equal(tt.want.Time) || got.Value != tt.want.Value || got.Source != tt.want.Source || got.Actor != tt.want.Actor {
+				t.Errorf("Unmarshal = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if data, _ := json.Marshal(Traced{}); string(data) != `{"value":null}` {
+		t.Errorf("Marshal zero = %s", data)
+	}
+}
+
+func TestMergeStructs_Traced(t *testing.T) {
+	type settings struct {
+		Beta Traced
+		Logs Traced
+	}
+	dst := settings{
+		Beta: Traced{Value: New(true), Source: "default"},
+		Logs: Traced{Value: New(true), Source: "default"},
+	}
+	src := settings{Beta: Traced{Value: New(false), Source: "tenant"}}
+	if err := MergeStructs(&dst, src); err != nil {
+		t.Fatalf("MergeStructs failed: %v", err)
+	}
+	if dst.Beta.Source != "tenant" || !dst.Beta.Value.IsFalse() {
+		t.Errorf("Beta = %+v, want tenant false", dst.Beta)
+	}
+	if dst.Logs.Source != "default" {
+		t.Errorf("Logs = %+v, want default kept", dst.Logs)
+	}
+}
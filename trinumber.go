@@ -0,0 +1,75 @@
+package tristate
+
+import "cmp"
+
+// Number is the set of types TriNumber can hold.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// TriNumber is a number that may be unset, with SQL-style arithmetic in
+// which an unset operand makes the result unset, so a value that was not
+// provided never silently becomes zero. It embeds Optional, so it has the
+// same accessors and codecs; its zero value is unset.
+type TriNumber[T Number] struct {
+	Optional[T]
+}
+
+// NumberOf returns a TriNumber set to v.
+func NumberOf[T Number](v T) TriNumber[T] {
+	return TriNumber[T]{Of(v)}
+}
+
+// Add returns n+o, or unset if either is unset.
+func (n TriNumber[T]) Add(o TriNumber[T]) TriNumber[T] {
+	if !n.set || !o.set {
+		return TriNumber[T]{}
+	}
+	return NumberOf(n.value + o.value)
+}
+
+// Sub returns n-o, or unset if either is unset.
+func (n TriNumber[T]) Sub(o TriNumber[T]) TriNumber[T] {
+	if !n.set || !o.set {
+		return TriNumber[T]{}
+	}
+	return NumberOf(n.value - o.value)
+}
+
+// Mul returns n*o, or unset if either is unset.
+func (n TriNumber[T]) Mul(o TriNumber[T]) TriNumber[T] {
+	if !n.set || !o.set {
+		return TriNumber[T]{}
+	}
+	return NumberOf(n.value * o.value)
+}
+
+// Compare returns -1, 0, or +1 as n is less than, equal to, or greater than
+// o, as cmp.Compare does, with ok false if either is unset.
+func (n TriNumber[T]) Compare(o TriNumber[T]) (c int, ok bool) {
+	if !n.set || !o.set {
+		return 0, false
+	}
+	return cmp.Compare(n.value, o.value), true
+}
+
+// Equal reports whether n equals o, or None if either is unset, as SQL's =
+// does.
+func (n TriNumber[T]) Equal(o TriNumber[T]) TriState {
+	c, ok := n.Compare(o)
+	if !ok {
+		return TriState{}
+	}
+	return New(c == 0)
+}
+
+// Less reports whether n is less than o, or None if either is unset.
+func (n TriNumber[T]) Less(o TriNumber[T]) TriState {
+	c, ok := n.Compare(o)
+	if !ok {
+		return TriState{}
+	}
+	return New(c < 0)
+}
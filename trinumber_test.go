@@ -0,0 +1,61 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTriNumber(t *testing.T) {
+	var unset TriNumber[int64]
+	a, b := NumberOf[int64](7), NumberOf[int64](3)
+
+	tests := []struct {
+		name string
+		got  TriNumber[int64]
+		want TriNumber[int64]
+	}{
+		{"Add", a.Add(b), NumberOf[int64](10)},
+		{"Sub", a.Sub(b), NumberOf[int64](4)},
+		{"Mul", a.Mul(b), NumberOf[int64](21)},
+		{"Add unset", a.Add(unset), unset},
+		{"Sub unset", unset.Sub(b), unset},
+		{"Mul by unset", NumberOf[int64](0).Mul(unset), unset},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if c, ok := a.Compare(b); !ok || c != 1 {
+		t.Errorf("Compare() = %d, %v", c, ok)
+	}
+	if _, ok := a.Compare(unset); ok {
+		t.Error("Compare with unset reported ok")
+	}
+	if got := a.Equal(a); !got.IsTrue() {
+		t.Errorf("Equal(self) = %v", got)
+	}
+	if got := b.Less(a); !got.IsTrue() {
+		t.Errorf("Less() = %v", got)
+	}
+	if got := unset.Equal(unset); !got.IsNone() {
+		t.Errorf("unset Equal(unset) = %v, want None", got)
+	}
+}
+
+func TestTriNumber_JSON(t *testing.T) {
+	var invoice struct {
+		Amount   TriNumber[float64] `json:"amount"`
+		Discount TriNumber[float64] `json:"discount"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 12.5, "discount": null}`), &invoice); err != nil {
+		t.Fatal(err)
+	}
+	if total := invoice.Amount.Sub(invoice.Discount); total.IsSet() {
+		t.Errorf("total with a missing discount = %v, want unset", total)
+	}
+	if out, _ := json.Marshal(invoice); string(out) != `{"amount":12.5,"discount":null}` {
+		t.Errorf("Marshal() = %s", out)
+	}
+}
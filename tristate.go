@@ -3,7 +3,10 @@ package tristate
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // State represents the underlying value of the TriState.
@@ -29,6 +32,14 @@ func New(v bool) TriState {
 	return TriState{value: False}
 }
 
+// FromPtr converts a *bool to a TriState, mapping nil to None.
+func FromPtr(p *bool) TriState {
+	if p == nil {
+		return TriState{}
+	}
+	return New(*p)
+}
+
 // --- Accessors ---
 
 func (t TriState) IsNone() bool  { return t.value == None }
@@ -56,6 +67,97 @@ func (t TriState) ValueOr(defaultVal bool) bool {
 	return defaultVal
 }
 
+// ErrRequired is returned by Required and ValidateStruct for values that
+// must be set explicitly but are None.
+var ErrRequired = errors.New("tristate: value required")
+
+// Required returns ErrRequired if t is None, and nil otherwise.
+func (t TriState) Required() error {
+	if t.IsNone() {
+		return ErrRequired
+	}
+	return nil
+}
+
+// Ptr converts t to a *bool, returning nil for None and a pointer to a
+// fresh bool otherwise.
+func (t TriState) Ptr() *bool {
+	v, ok := t.Bool()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// --- Parsing ---
+
+// Parse converts a string to a TriState. It accepts every form understood
+// by strconv.ParseBool, and maps the empty string, "none", "null", and
+// "unset" (in any case) to None. Surrounding whitespace is ignored.
+func Parse(s string) (TriState, error) {
+	raw := s
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "", "none", "null", "unset":
+		return TriState{}, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return TriState{}, reject("parse", raw, fmt.Errorf("invalid tristate value: %q (want true, false, or none)", s))
+	}
+	return New(b), nil
+}
+
+// Set parses value with Parse and stores the result. It implements the
+// Setter interface of kelseyhightower/envconfig, so an empty variable
+// decodes to None.
+func (t *TriState) Set(value string) error {
+	v, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// Decode is Set under the name expected by envconfig's Decoder interface.
+func (t *TriState) Decode(value string) error { return t.Set(value) }
+
+// --- Command-Line Flags ---
+
+// String returns "true", "false", or "none". Together with Set it makes
+// *TriState a flag.Value:
+//
+//	flag.Var(&cfg.Audit, "audit", "enable auditing")
+//
+// An omitted flag leaves the value None, and -audit=true or -audit=false
+// sets it explicitly.
+func (t TriState) String() string {
+	switch t.value {
+	case True:
+		return "true"
+	case False:
+		return "false"
+	default:
+		return "none"
+	}
+}
+
+// IsBoolFlag reports true so the flag package accepts a bare -audit as
+// -audit=true. As with bool flags, a false value must be written with '='.
+// Kingpin honours it too, adding a --no-audit negation that sets False.
+func (t *TriState) IsBoolFlag() bool { return true }
+
+// Type returns "tristate", completing the spf13/pflag Value interface.
+// pflag ignores IsBoolFlag; set NoOptDefVal so a bare --audit means True:
+//
+//	cmd.Flags().Var(&cfg.Audit, "audit", "enable auditing")
+//	cmd.Flags().Lookup("audit").NoOptDefVal = "true"
+//
+// Then --audit gives True, --audit=false gives False, and omitting the
+// flag leaves None.
+func (t *TriState) Type() string { return "tristate" }
+
 // --- JSON Marshaling ---
 
 // MarshalJSON converts the TriState to true, false, or null.
@@ -84,5 +186,5 @@ func (t *TriState) UnmarshalJSON(data []byte) error {
 		t.value = False
 		return nil
 	}
-	return fmt.Errorf("invalid tristate value: %s", string(data))
+	return reject("json", string(data), fmt.Errorf("invalid tristate value: %s", string(data)))
 }
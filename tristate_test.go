@@ -2,6 +2,9 @@ package tristate
 
 import (
 	"encoding/json"
+	"flag"
+	"io"
+	"strings"
 	"testing"
 )
 
@@ -81,3 +84,103 @@ func TestTriState_ValueOr(t *testing.T) {
 func bytesContains(data []byte, sub string) bool {
 	return string(data) != "{}" // Simplified check for this snippet
 }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    State
+		wantErr bool
+	}{
+		{"true", True, false},
+		{"TRUE", True, false},
+		{"1", True, false},
+		{" t ", True, false},
+		{"false", False, false},
+		{"0", False, false},
+		{"F", False, false},
+		{"", None, false},
+		{"none", None, false},
+		{"Null", None, false},
+		{"unset", None, false},
+		{"maybe", None, true},
+		{"2", None, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got.value != tt.want {
+				t.Errorf("Parse(%q) = %v, want %v", tt.input, got.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriState_Ptr(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name string
+		ptr  *bool
+		want State
+	}{
+		{"nil", nil, None},
+		{"true", &yes, True},
+		{"false", &no, False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromPtr(tt.ptr)
+			if got.value != tt.want {
+				t.Fatalf("FromPtr() = %v, want %v", got.value, tt.want)
+			}
+			back := got.Ptr()
+			if (back == nil) != (tt.ptr == nil) || (back != nil && *back != *tt.ptr) {
+				t.Errorf("Ptr() = %v, want %v", back, tt.ptr)
+			}
+			if back != nil && back == tt.ptr {
+				t.Error("Ptr() returned the original pointer")
+			}
+		})
+	}
+}
+
+func TestTriState_Flag(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want State
+	}{
+		{"Omitted", nil, None},
+		{"Bare", []string{"-audit"}, True},
+		{"Explicit true", []string{"-audit=true"}, True},
+		{"Explicit false", []string{"-audit=false"}, False},
+		{"Explicit none", []string{"-audit=none"}, None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var audit TriState
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.Var(&audit, "audit", "enable auditing")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if audit.value != tt.want {
+				t.Errorf("audit = %v, want %v", audit.value, tt.want)
+			}
+			if got := audit.String(); got != map[State]string{None: "none", False: "false", True: "true"}[tt.want] {
+				t.Errorf("String() = %q", got)
+			}
+		})
+	}
+
+	var audit TriState
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&audit, "audit", "enable auditing")
+	err := fs.Parse([]string{"-audit=sometimes"})
+	if err == nil || !strings.Contains(err.Error(), "want true, false, or none") {
+		t.Errorf("Parse(-audit=sometimes) error = %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Package tristatecasbin converts between Casbin effects and
+// tristate.Policy, so authorizers built on this package can consume and
+// produce the decisions of Casbin models.
+//
+// Allow and Deny map to themselves and Indeterminate maps to Inherit.
+// Merge combines the effects of matched rules using the strategy named by
+// a model's policy_effect expression:
+//
+//	p, err := tristatecasbin.Merge(m["e"]["e"].Value, effects, matches)
+package tristatecasbin
+
+import (
+	"fmt"
+
+	"github.com/casbin/casbin/v2/constant"
+	"github.com/casbin/casbin/v2/effector"
+
+	"tristate"
+)
+
+// FromEffect converts a Casbin effect to a Policy.
+func FromEffect(e effector.Effect) tristate.Policy {
+	switch e {
+	case effector.Allow:
+		return tristate.Allow()
+	case effector.Deny:
+		return tristate.Deny()
+	default:
+		return tristate.Inherit()
+	}
+}
+
+// ToEffect converts a Policy to a Casbin effect.
+func ToEffect(p tristate.Policy) effector.Effect {
+	switch {
+	case p.IsAllow():
+		return effector.Allow
+	case p.IsDeny():
+		return effector.Deny
+	default:
+		return effector.Indeterminate
+	}
+}
+
+// Strategy returns the combining function for a Casbin policy_effect
+// expression: tristate.AllowOverrides for "some(where (p_eft == allow))",
+// and tristate.DenyOverrides for "!some(where (p_eft == deny))" and for
+// the allow-and-deny expression. Other expressions are an error.
+//
+// Unlike Casbin, the strategies return Inherit when no rule matched, where
+// Casbin's deny-override would allow; apply the model's default with
+// p.TriState().ValueOr.
+func Strategy(expr string) (func(...tristate.Policy) tristate.Policy, error) {
+	switch expr {
+	case constant.AllowOverrideEffect:
+		return tristate.AllowOverrides, nil
+	case constant.DenyOverrideEffect, constant.AllowAndDenyEffect:
+		return tristate.DenyOverrides, nil
+	default:
+		return nil, fmt.Errorf("tristatecasbin: unsupported casbin policy effect %q", expr)
+	}
+}
+
+// Merge combines the effects of the rules whose matches entry is non-zero,
+// as the Casbin enforcer passes them to an Effector, using Strategy(expr).
+func Merge(expr string, effects []effector.Effect, matches []float64) (tristate.Policy, error) {
+	combine, err := Strategy(expr)
+	if err != nil {
+		return tristate.Inherit(), err
+	}
+	if len(effects) != len(matches) {
+		return tristate.Inherit(), fmt.Errorf("tristatecasbin: %d effects but %d matches", len(effects), len(matches))
+	}
+	ps := make([]tristate.Policy, 0, len(effects))
+	for i, e := range effects {
+		if matches[i] != 0 {
+			ps = append(ps, FromEffect(e))
+		}
+	}
+	return combine(ps...), nil
+}
@@ -0,0 +1,65 @@
+package tristatecasbin
+
+import (
+	"testing"
+
+	"github.com/casbin/casbin/v2/constant"
+	"github.com/casbin/casbin/v2/effector"
+
+	"tristate"
+)
+
+func TestEffect(t *testing.T) {
+	tests := []struct {
+		effect effector.Effect
+		policy tristate.Policy
+	}{
+		{effector.Allow, tristate.Allow()},
+		{effector.Deny, tristate.Deny()},
+		{effector.Indeterminate, tristate.Inherit()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			if got := FromEffect(tt.effect); got != tt.policy {
+				t.Errorf("FromEffect() = %v, want %v", got, tt.policy)
+			}
+			if got := ToEffect(tt.policy); got != tt.effect {
+				t.Errorf("ToEffect() = %v, want %v", got, tt.effect)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	effects := []effector.Effect{effector.Allow, effector.Deny, effector.Allow}
+	tests := []struct {
+		name    string
+		expr    string
+		matches []float64
+		want    tristate.Policy
+	}{
+		{"Deny overrides", constant.DenyOverrideEffect, []float64{1, 1, 0}, tristate.Deny()},
+		{"Allow overrides", constant.AllowOverrideEffect, []float64{1, 1, 0}, tristate.Allow()},
+		{"Allow and deny", constant.AllowAndDenyEffect, []float64{0, 0, 1}, tristate.Allow()},
+		{"Unmatched deny ignored", constant.DenyOverrideEffect, []float64{1, 0, 0}, tristate.Allow()},
+		{"Nothing matched", constant.DenyOverrideEffect, []float64{0, 0, 0}, tristate.Inherit()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Merge(tt.expr, effects, tt.matches)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Merge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Merge(constant.PriorityEffect, effects, []float64{1, 1, 1}); err == nil {
+		t.Error("Merge() with priority effect succeeded, want error")
+	}
+	if _, err := Merge(constant.DenyOverrideEffect, effects, []float64{1}); err == nil {
+		t.Error("Merge() with mismatched lengths succeeded, want error")
+	}
+}
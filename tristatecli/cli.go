@@ -0,0 +1,63 @@
+// Package tristatecli provides a tri-state flag type for urfave/cli v3.
+//
+// A TriStateFlag behaves like a BoolFlag that remembers whether it was
+// given: a bare --audit means True, --audit=false means False, and a flag
+// that is neither passed nor found in its Sources stays None:
+//
+//	var audit tristate.TriState
+//	cmd := &cli.Command{
+//		Flags: []cli.Flag{
+//			&tristatecli.TriStateFlag{
+//				Name:        "audit",
+//				Sources:     cli.EnvVars("APP_AUDIT"),
+//				Destination: &audit,
+//			},
+//		},
+//	}
+//
+// urfave/cli v2 has no generic flag base; there, *tristate.TriState already
+// satisfies flag.Value and can be passed as the Value of a cli.GenericFlag.
+package tristatecli
+
+import (
+	"github.com/urfave/cli/v3"
+
+	"tristate"
+)
+
+// TriStateFlag is a cli.Flag holding a tristate.TriState. Its Value field
+// sets the default, which is None if left zero.
+type TriStateFlag = cli.FlagBase[tristate.TriState, cli.NoConfig, triStateValue]
+
+// Get returns the value of the named TriStateFlag on cmd, or None if cmd
+// has no such flag.
+func Get(cmd *cli.Command, name string) tristate.TriState {
+	v, _ := cmd.Value(name).(tristate.TriState)
+	return v
+}
+
+// triStateValue is the cli.ValueCreator behind TriStateFlag.
+type triStateValue struct {
+	destination *tristate.TriState
+}
+
+// Create stores the default val in p and returns a Value writing to p.
+func (triStateValue) Create(val tristate.TriState, p *tristate.TriState, _ cli.NoConfig) cli.Value {
+	*p = val
+	return &triStateValue{destination: p}
+}
+
+// ToString formats a value for help output.
+func (triStateValue) ToString(val tristate.TriState) string { return val.String() }
+
+func (v *triStateValue) Set(s string) error { return v.destination.Set(s) }
+func (v *triStateValue) Get() any           { return *v.destination }
+func (v *triStateValue) String() string {
+	if v.destination == nil {
+		return tristate.TriState{}.String()
+	}
+	return v.destination.String()
+}
+
+// IsBoolFlag lets the flag be given without a value, meaning True.
+func (v *triStateValue) IsBoolFlag() bool { return true }
@@ -0,0 +1,77 @@
+package tristatecli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+
+	"tristate"
+)
+
+func TestTriStateFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		args []string
+		want tristate.TriState
+	}{
+		{"Omitted", "", nil, tristate.TriState{}},
+		{"Bare", "", []string{"--audit"}, tristate.New(true)},
+		{"Explicit false", "", []string{"--audit=false"}, tristate.New(false)},
+		{"Explicit none", "", []string{"--audit=none"}, tristate.TriState{}},
+		{"Env true", "1", nil, tristate.New(true)},
+		{"Env false", "false", nil, tristate.New(false)},
+		{"Flag beats env", "false", []string{"--audit"}, tristate.New(true)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("APP_AUDIT", tt.env)
+			}
+			var dest, got tristate.TriState
+			cmd := &cli.Command{
+				Name: "app",
+				Flags: []cli.Flag{
+					&TriStateFlag{Name: "audit", Sources: cli.EnvVars("APP_AUDIT"), Destination: &dest},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					got = Get(cmd, "audit")
+					return nil
+				},
+			}
+			err := cmd.Run(context.Background(), append([]string{"app"}, tt.args...))
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if dest != tt.want || got != tt.want {
+				t.Errorf("Destination = %v, Get = %v, want %v", dest, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTriStateFlag_Invalid(t *testing.T) {
+	cmd := &cli.Command{Name: "app", Flags: []cli.Flag{&TriStateFlag{Name: "audit"}}}
+	if err := cmd.Run(context.Background(), []string{"app", "--audit=sometimes"}); err == nil {
+		t.Error("Run accepted an invalid value")
+	}
+}
+
+func TestTriStateFlag_Default(t *testing.T) {
+	var got tristate.TriState
+	cmd := &cli.Command{
+		Name:  "app",
+		Flags: []cli.Flag{&TriStateFlag{Name: "audit", Value: tristate.New(false)}},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			got = Get(cmd, "audit")
+			return nil
+		},
+	}
+	if err := cmd.Run(context.Background(), []string{"app"}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !got.IsFalse() {
+		t.Errorf("Get = %v, want false", got)
+	}
+}
@@ -0,0 +1,30 @@
+// Package tristatecmp lets github.com/google/go-cmp compare and diff
+// values containing tristate types, which otherwise panic on their
+// unexported fields:
+//
+//	if diff := cmp.Diff(want, got, tristatecmp.Option()); diff != "" {
+//		t.Errorf("config mismatch (-want +got):\n%s", diff)
+//	}
+//
+// Diffs print TriState values as true, false, or none.
+package tristatecmp
+
+import (
+	"slices"
+
+	"github.com/google/go-cmp/cmp"
+
+	"tristate"
+)
+
+// Option returns a cmp.Option comparing TriState and Slice values,
+// including those nested in structs, maps, and slices. Slices compare
+// element by element, regardless of spare capacity in their packing.
+func Option() cmp.Option {
+	return cmp.Options{
+		cmp.Comparer(func(a, b tristate.TriState) bool { return a == b }),
+		cmp.Transformer("tristate.Slice", func(s tristate.Slice) []tristate.TriState {
+			return slices.Collect(s.Values())
+		}),
+	}
+}
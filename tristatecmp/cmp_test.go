@@ -0,0 +1,41 @@
+package tristatecmp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"tristate"
+)
+
+type config struct {
+	Name   string
+	Audit  tristate.TriState
+	Flags  tristate.Map
+	Checks tristate.Slice
+}
+
+func TestOption(t *testing.T) {
+	want := config{
+		Name:   "svc",
+		Audit:  tristate.New(true),
+		Flags:  tristate.Map{"beta": tristate.New(false)},
+		Checks: tristate.SliceOf(tristate.New(true), tristate.TriState{}),
+	}
+	same := want
+	same.Flags = tristate.Map{"beta": tristate.New(false)}
+	same.Checks = tristate.SliceOf(tristate.New(true), tristate.TriState{})
+	if diff := cmp.Diff(want, same, Option()); diff != "" {
+		t.Errorf("equal configs differ:\n%s", diff)
+	}
+
+	got := same
+	got.Audit = tristate.TriState{}
+	diff := cmp.Diff(want, got, Option())
+	for _, s := range []string{"Audit", "true", "none"} {
+		if !strings.Contains(diff, s) {
+			t.Errorf("diff does not mention %q:\n%s", s, diff)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package tristatecobra wires tristate.TriState flags into spf13/cobra
+// commands, including shell completion of their values.
+//
+// Var registers a flag that behaves like a tri-state bool: a bare --audit
+// means True, --audit=false means False, and omitting the flag leaves None.
+// Its values complete in bash, zsh, fish, and PowerShell:
+//
+//	var audit tristate.TriState
+//	tristatecobra.Var(cmd, &audit, "audit", "enable auditing")
+package tristatecobra
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"tristate"
+)
+
+// completions are offered for every TriState flag.
+var completions = []cobra.Completion{
+	cobra.CompletionWithDesc("true", "explicitly enable"),
+	cobra.CompletionWithDesc("false", "explicitly disable"),
+	cobra.CompletionWithDesc("none", "leave unset and inherit"),
+	cobra.CompletionWithDesc("unset", "same as none"),
+}
+
+// Complete is a cobra.CompletionFunc offering "true", "false", "none", and "unset",
+// filtered by the prefix typed so far. File completion is disabled.
+func Complete(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var out []cobra.Completion
+	for _, c := range completions {
+		if strings.HasPrefix(c, strings.ToLower(toComplete)) {
+			out = append(out, c)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// RegisterCompletion registers Complete for each named flag of cmd. It
+// returns an error if a flag does not exist or already has a completion.
+func RegisterCompletion(cmd *cobra.Command, names ...string) error {
+	for _, name := range names {
+		if err := cmd.RegisterFlagCompletionFunc(name, Complete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Var defines a TriState flag on cmd with NoOptDefVal set to "true" and
+// registers its completion. Like cobra's own flag helpers, it panics if
+// the flag is already defined.
+func Var(cmd *cobra.Command, p *tristate.TriState, name, usage string) {
+	cmd.Flags().Var(p, name, usage)
+	cmd.Flags().Lookup(name).NoOptDefVal = "true"
+	if err := RegisterCompletion(cmd, name); err != nil {
+		panic("tristatecobra: " + err.Error())
+	}
+}
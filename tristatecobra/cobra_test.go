@@ -0,0 +1,90 @@
+package tristatecobra
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"tristate"
+)
+
+func newCommand(audit *tristate.TriState) *cobra.Command {
+	cmd := &cobra.Command{Use: "app", Run: func(*cobra.Command, []string) {}}
+	Var(cmd, audit, "audit", "enable auditing")
+	return cmd
+}
+
+func TestVar(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want tristate.TriState
+	}{
+		{"Omitted", nil, tristate.TriState{}},
+		{"Bare", []string{"--audit"}, tristate.New(true)},
+		{"Explicit false", []string{"--audit=false"}, tristate.New(false)},
+		{"Explicit none", []string{"--audit=none"}, tristate.TriState{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var audit tristate.TriState
+			cmd := newCommand(&audit)
+			cmd.SetArgs(tt.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if audit != tt.want {
+				t.Errorf("audit = %v, want %v", audit, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		toComplete string
+		want       []string
+	}{
+		{"", []string{"true", "false", "none", "unset"}},
+		{"u", []string{"unset"}},
+		{"f", []string{"false"}},
+		{"T", []string{"true"}},
+		{"x", nil},
+	}
+	for _, tt := range tests {
+		got, directive := Complete(nil, nil, tt.toComplete)
+		var names []string
+		for _, c := range got {
+			names = append(names, strings.SplitN(c, "\t", 2)[0])
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("Complete(%q) = %v, want %v", tt.toComplete, names, tt.want)
+		}
+		if directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("Complete(%q) directive = %v", tt.toComplete, directive)
+		}
+	}
+}
+
+func TestVar_ShellCompletion(t *testing.T) {
+	var audit tristate.TriState
+	cmd := newCommand(&audit)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{cobra.ShellCompNoDescRequestCmd, "--audit=n"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got := strings.SplitN(out.String(), "\n", 2)[0]; got != "none" {
+		t.Errorf("completion = %q, want none", got)
+	}
+
+	if err := RegisterCompletion(cmd, "missing"); err == nil {
+		t.Error("RegisterCompletion accepted an undefined flag")
+	}
+}
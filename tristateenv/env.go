@@ -0,0 +1,41 @@
+// Package tristateenv lets caarlos0/env populate tristate.TriState fields.
+//
+// Register the parser through env.Options.FuncMap, or call Parse which does
+// so for you:
+//
+//	var cfg struct {
+//		FeatureX tristate.TriState `env:"FEATURE_X"`
+//	}
+//	err := env.ParseWithOptions(&cfg, env.Options{FuncMap: tristateenv.FuncMap()})
+//
+// env only invokes the parser for variables that are present, so an unset
+// FEATURE_X leaves the field None while FEATURE_X=false sets it to False.
+package tristateenv
+
+import (
+	"reflect"
+
+	"github.com/caarlos0/env/v11"
+
+	"tristate"
+)
+
+// Parser returns an env.ParserFunc that parses values with tristate.Parse.
+func Parser() env.ParserFunc {
+	return func(v string) (any, error) {
+		return tristate.Parse(v)
+	}
+}
+
+// FuncMap returns the env.Options.FuncMap entries needed for TriState
+// fields. Merge it into an existing FuncMap when registering other parsers.
+func FuncMap() map[reflect.Type]env.ParserFunc {
+	return map[reflect.Type]env.ParserFunc{
+		reflect.TypeFor[tristate.TriState](): Parser(),
+	}
+}
+
+// Parse is env.Parse with TriState support registered.
+func Parse(v any) error {
+	return env.ParseWithOptions(v, env.Options{FuncMap: FuncMap()})
+}
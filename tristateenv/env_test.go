@@ -0,0 +1,55 @@
+package tristateenv
+
+import (
+	"testing"
+
+	"github.com/caarlos0/env/v11"
+
+	"tristate"
+)
+
+type config struct {
+	FeatureX tristate.TriState  `env:"FEATURE_X"`
+	FeatureY tristate.TriState  `env:"FEATURE_Y"`
+	FeatureZ tristate.TriState  `env:"FEATURE_Z"`
+	Default  tristate.TriState  `env:"FEATURE_D" envDefault:"true"`
+	Pointer  *tristate.TriState `env:"FEATURE_P"`
+}
+
+func TestParse(t *testing.T) {
+	t.Setenv("FEATURE_X", "false")
+	t.Setenv("FEATURE_Y", "1")
+	t.Setenv("FEATURE_P", "true")
+
+	var cfg config
+	if err := Parse(&cfg); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		got  tristate.TriState
+		want bool
+		set  bool
+	}{
+		{"Explicit false", cfg.FeatureX, false, true},
+		{"Explicit true", cfg.FeatureY, true, true},
+		{"Unset", cfg.FeatureZ, false, false},
+		{"envDefault", cfg.Default, true, true},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.got.Bool(); got != tt.want || ok != tt.set {
+			t.Errorf("%s: Bool() = (%v, %v), want (%v, %v)", tt.name, got, ok, tt.want, tt.set)
+		}
+	}
+	if cfg.Pointer == nil || !cfg.Pointer.IsTrue() {
+		t.Errorf("Pointer = %v, want True", cfg.Pointer)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Setenv("FEATURE_X", "sometimes")
+	var cfg config
+	if err := env.ParseWithOptions(&cfg, env.Options{FuncMap: FuncMap()}); err == nil {
+		t.Error("ParseWithOptions accepted an invalid value")
+	}
+}
@@ -0,0 +1,60 @@
+// Package tristategopter provides github.com/leanovate/gopter generators
+// for tristate values, so property tests of merge and logic code need no
+// generator boilerplate:
+//
+//	properties.Property("override wins", prop.ForAll(
+//		func(a, b tristate.Map) bool { ... },
+//		tristategopter.Map(tristategopter.TriState()),
+//		tristategopter.Map(tristategopter.MostlyNone()),
+//	))
+package tristategopter
+
+import (
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+
+	"tristate"
+)
+
+// TriState returns a generator choosing None, False, and True with equal
+// probability.
+func TriState() gopter.Gen {
+	return Weighted(1, 1, 1)
+}
+
+// MostlyNone returns a generator producing None four times in five, as in
+// sparse override sets where most settings are left unset.
+func MostlyNone() gopter.Gen {
+	return Weighted(8, 1, 1)
+}
+
+// Weighted returns a generator choosing None, False, and True with
+// probabilities proportional to the given weights. It panics if a weight
+// is negative or all are zero.
+func Weighted(none, falseW, trueW int) gopter.Gen {
+	if none < 0 || falseW < 0 || trueW < 0 || none+falseW+trueW == 0 {
+		panic("tristategopter: invalid weights")
+	}
+	var gens []gen.WeightedGen
+	for _, w := range []struct {
+		weight int
+		value  tristate.TriState
+	}{
+		{none, tristate.TriState{}},
+		{falseW, tristate.New(false)},
+		{trueW, tristate.New(true)},
+	} {
+		if w.weight > 0 {
+			gens = append(gens, gen.WeightedGen{Weight: w.weight, Gen: gen.Const(w.value)})
+		}
+	}
+	return gen.Weighted(gens)
+}
+
+// Map returns a generator of Maps with short lowercase keys and values
+// drawn from values.
+func Map(values gopter.Gen) gopter.Gen {
+	return gen.MapOf(gen.RegexMatch(`^[a-z]{1,8}$`), values).Map(func(m map[string]tristate.TriState) tristate.Map {
+		return m
+	})
+}
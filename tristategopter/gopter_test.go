@@ -0,0 +1,62 @@
+package tristategopter
+
+import (
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+
+	"tristate"
+)
+
+func TestWeighted(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  gopter.Gen
+		want [3]bool // whether None, False, and True may appear
+	}{
+		{"Uniform", TriState(), [3]bool{true, true, true}},
+		{"MostlyNone", MostlyNone(), [3]bool{true, true, true}},
+		{"OnlyFalse", Weighted(0, 1, 0), [3]bool{false, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen [3]bool
+			for range 200 {
+				v, ok := tt.gen.Sample()
+				if !ok {
+					t.Fatal("generator produced no value")
+				}
+				switch v := v.(tristate.TriState); {
+				case v.IsNone():
+					seen[0] = true
+				case v.IsFalse():
+					seen[1] = true
+				default:
+					seen[2] = true
+				}
+			}
+			if seen != tt.want {
+				t.Errorf("states seen = %v, want %v", seen, tt.want)
+			}
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	properties := gopter.NewProperties(nil)
+	properties.Property("override keeps explicit values", prop.ForAll(
+		func(a, b tristate.Map) bool {
+			merged := a.MergeOverride(b)
+			for k, v := range b {
+				if !v.IsNone() && merged[k] != v {
+					return false
+				}
+			}
+			return true
+		},
+		Map(TriState()),
+		Map(MostlyNone()),
+	))
+	properties.TestingRun(t)
+}
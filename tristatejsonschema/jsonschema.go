@@ -0,0 +1,45 @@
+// Package tristatejsonschema describes tristate.TriState fields in JSON
+// Schemas (draft 2020-12) generated by invopop/jsonschema, so editors
+// validating configuration files accept true, false, and null:
+//
+//	r := &jsonschema.Reflector{Mapper: tristatejsonschema.Mapper}
+//	schema := r.Reflect(&Config{})
+//
+// invopop's own customization hook, a JSONSchema method on the type, would
+// force every user of package tristate to depend on invopop; Mapper gives
+// the same result from outside.
+package tristatejsonschema
+
+import (
+	"reflect"
+
+	"github.com/invopop/jsonschema"
+
+	"tristate"
+)
+
+var triStateType = reflect.TypeFor[tristate.TriState]()
+
+// Schema returns the schema of a marshaled TriState:
+//
+//	{"type": ["boolean", "null"], "description": "..."}
+//
+// invopop's Schema.Type holds a single type, so the two-element type is
+// carried in Extras.
+func Schema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Description: tristate.TriState{}.Schema()["description"].(string),
+		Extras:      map[string]any{"type": []string{"boolean", "null"}},
+	}
+}
+
+// Mapper is a jsonschema.Reflector Mapper returning Schema for TriState and
+// nil, meaning the default reflection, for every other type. As for any
+// field, the reflector replaces the description with the field's own
+// jsonschema_description tag or doc comment.
+func Mapper(t reflect.Type) *jsonschema.Schema {
+	if t != triStateType {
+		return nil
+	}
+	return Schema()
+}
@@ -0,0 +1,80 @@
+package tristatejsonschema
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/invopop/jsonschema"
+
+	"tristate"
+)
+
+type config struct {
+	Audit  tristate.TriState  `json:"audit"`
+	Beta   *tristate.TriState `json:"beta,omitempty"`
+	Name   string             `json:"name"`
+	Nested struct {
+		TLS tristate.TriState `json:"tls"`
+	} `json:"nested"`
+}
+
+func TestMapper(t *testing.T) {
+	r := &jsonschema.Reflector{Mapper: Mapper, DoNotReference: true}
+	data, err := json.Marshal(r.Reflect(&config{}))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	type prop struct {
+		Type        any    `json:"type"`
+		Description string `json:"description"`
+		Properties  map[string]prop
+	}
+	var got struct {
+		Schema     string          `json:"$schema"`
+		Properties map[string]prop `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.Schema != "https://json-schema.org/draft/2020-12/schema" {
+		t.Errorf("$schema = %q", got.Schema)
+	}
+
+	isTriState := func(p prop) bool {
+		types, _ := p.Type.([]any)
+		return slices.Equal(types, []any{"boolean", "null"})
+	}
+	for _, p := range []prop{got.Properties["audit"], got.Properties["beta"], got.Properties["nested"].Properties["tls"]} {
+		if !isTriState(p) {
+			t.Errorf("TriState property = %+v in %s", p, data)
+		}
+	}
+	if d := got.Properties["audit"].Description; d != "" {
+		t.Errorf("audit description = %q, want the field's own (empty)", d)
+	}
+	if got.Properties["name"].Type != "string" {
+		t.Errorf("name property = %+v", got.Properties["name"])
+	}
+}
+
+func TestSchema(t *testing.T) {
+	data, err := json.Marshal(Schema())
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got struct {
+		Type        []string `json:"type"`
+		Description string   `json:"description"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !slices.Equal(got.Type, []string{"boolean", "null"}) || got.Description == "" {
+		t.Errorf("Schema() = %s", data)
+	}
+	if Schema() == Schema() {
+		t.Error("Schema() returned a shared value")
+	}
+}
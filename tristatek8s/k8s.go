@@ -0,0 +1,163 @@
+// Package tristatek8s bridges tristate.TriState and the optional *bool
+// fields used throughout Kubernetes API types and CRD specs.
+//
+// It works on any typed object through reflection and json tags, so it
+// does not depend on k8s.io/api or apimachinery.
+package tristatek8s
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"tristate"
+)
+
+// FromPtr converts an optional *bool field to a TriState; nil is None.
+func FromPtr(p *bool) tristate.TriState { return tristate.FromPtr(p) }
+
+// ToPtr converts a TriState to a *bool suitable for an optional field;
+// None becomes nil, like leaving the field unset.
+func ToPtr(t tristate.TriState) *bool { return t.Ptr() }
+
+var (
+	boolType    = reflect.TypeFor[bool]()
+	boolPtrType = reflect.TypeFor[*bool]()
+)
+
+// ApplyOverlay sets boolean fields of obj from overlay, which maps
+// dot-separated json field paths such as "spec.template.spec.hostNetwork"
+// to values. Set entries overwrite the addressed *bool or bool field; None
+// entries leave it untouched, so one overlay can be layered over many
+// objects. Nil struct pointers along a path are allocated. Paths through
+// slices or maps are not supported. If any set entry names a missing or
+// non-boolean field, ApplyOverlay returns an error and leaves obj
+// unchanged.
+//
+// obj must be a non-nil pointer to a struct, such as *appsv1.Deployment.
+func ApplyOverlay(obj any, overlay tristate.Map) error {
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("tristatek8s: ApplyOverlay requires a non-nil struct pointer, got %T", obj)
+	}
+	// Resolve every path against a scratch value first, so an invalid
+	// entry is reported before obj is modified. Paths are visited in
+	// sorted order so the reported error does not depend on map order.
+	paths := slices.Sorted(maps.Keys(overlay))
+	scratch := reflect.New(rv.Elem().Type()).Elem()
+	for _, path := range paths {
+		if overlay[path].IsNone() {
+			continue
+		}
+		field, err := resolve(scratch, strings.Split(path, "."))
+		if err != nil {
+			return fmt.Errorf("tristatek8s: overlay path %q: %w", path, err)
+		}
+		if t := field.Type(); t != boolPtrType && t != boolType {
+			return fmt.Errorf("tristatek8s: overlay path %q: field has type %s, want bool or *bool", path, t)
+		}
+	}
+	for _, path := range paths {
+		b, ok := overlay[path].Bool()
+		if !ok {
+			continue
+		}
+		field, _ := resolve(rv.Elem(), strings.Split(path, "."))
+		if field.Type() == boolPtrType {
+			field.Set(reflect.ValueOf(&b))
+		} else {
+			field.SetBool(b)
+		}
+	}
+	return nil
+}
+
+// Extract reads the boolean fields of obj named by paths into a Map,
+// the inverse of ApplyOverlay. Nil *bool fields, and paths through nil
+// struct pointers, are reported as None.
+func Extract(obj any, paths ...string) (tristate.Map, error) {
+	rv := reflect.Indirect(reflect.ValueOf(obj))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tristatek8s: Extract requires a struct, got %T", obj)
+	}
+	out := tristate.Map{}
+	for _, path := range paths {
+		v, err := extract(rv, strings.Split(path, "."))
+		if err != nil {
+			return nil, fmt.Errorf("tristatek8s: path %q: %w", path, err)
+		}
+		out[path] = v
+	}
+	return out, nil
+}
+
+func extract(v reflect.Value, path []string) (tristate.TriState, error) {
+	for _, name := range path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return tristate.TriState{}, nil
+			}
+			v = v.Elem()
+		}
+		f, err := field(v, name)
+		if err != nil {
+			return tristate.TriState{}, err
+		}
+		v = f
+	}
+	switch v.Type() {
+	case boolPtrType:
+		return FromPtr(v.Interface().(*bool)), nil
+	case boolType:
+		return tristate.New(v.Bool()), nil
+	default:
+		return tristate.TriState{}, fmt.Errorf("field has type %s, want bool or *bool", v.Type())
+	}
+}
+
+func resolve(v reflect.Value, path []string) (reflect.Value, error) {
+	for _, name := range path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		f, err := field(v, name)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v = f
+	}
+	return v, nil
+}
+
+// field returns the field of struct v with the given json name, looking
+// through embedded structs such as metav1.TypeMeta.
+func field(v reflect.Value, name string) (reflect.Value, error) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("cannot select %q from %s", name, v.Type())
+	}
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		tag, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if sf.Anonymous && (tag == "" || strings.Contains(opts, "inline")) {
+			if f, err := field(reflect.Indirect(v.Field(i)), name); err == nil {
+				return f, nil
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if tag == "" {
+			tag = sf.Name
+		}
+		if tag == name {
+			return v.Field(i), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("no field %q in %s", name, v.Type())
+}
@@ -0,0 +1,127 @@
+package tristatek8s
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"tristate"
+)
+
+// Minimal stand-ins for Kubernetes API types, with the same json tag
+// conventions.
+type objectMeta struct {
+	Name string `json:"name,omitempty"`
+}
+
+type securityContext struct {
+	RunAsNonRoot             *bool `json:"runAsNonRoot,omitempty"`
+	AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation,omitempty"`
+}
+
+type podSpec struct {
+	HostNetwork     bool             `json:"hostNetwork,omitempty"`
+	SecurityContext *securityContext `json:"securityContext,omitempty"`
+}
+
+type widgetSpec struct {
+	Suspend *bool   `json:"suspend,omitempty"`
+	Pod     podSpec `json:"pod"`
+}
+
+type widget struct {
+	objectMeta `json:"metadata,inline"`
+	Spec       widgetSpec `json:"spec"`
+}
+
+func TestApplyOverlay(t *testing.T) {
+	yes := true
+	obj := &widget{Spec: widgetSpec{Suspend: &yes}}
+
+	overlay := tristate.Map{
+		"spec.suspend":                                      tristate.New(false),
+		"spec.pod.hostNetwork":                              tristate.New(true),
+		"spec.pod.securityContext.runAsNonRoot":             tristate.New(true),
+		"spec.pod.securityContext.allowPrivilegeEscalation": {},
+	}
+	if err := ApplyOverlay(obj, overlay); err != nil {
+		t.Fatalf("ApplyOverlay failed: %v", err)
+	}
+	if obj.Spec.Suspend == nil || *obj.Spec.Suspend {
+		t.Errorf("Suspend = %v, want false", obj.Spec.Suspend)
+	}
+	if !yes {
+		t.Error("ApplyOverlay wrote through the original pointer")
+	}
+	if !obj.Spec.Pod.HostNetwork {
+		t.Error("HostNetwork not set")
+	}
+	sc := obj.Spec.Pod.SecurityContext
+	if sc == nil || sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+		t.Errorf("SecurityContext = %+v, want runAsNonRoot", sc)
+	}
+	if sc.AllowPrivilegeEscalation != nil {
+		t.Error("None overlay entry set a field")
+	}
+
+	got, err := Extract(obj, "spec.suspend", "spec.pod.hostNetwork", "spec.pod.securityContext.allowPrivilegeEscalation")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	want := tristate.Map{
+		"spec.suspend":         tristate.New(false),
+		"spec.pod.hostNetwork": tristate.New(true),
+		"spec.pod.securityContext.allowPrivilegeEscalation": {},
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("Extract = %v, want %v", got, want)
+	}
+}
+
+func TestApplyOverlay_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     any
+		overlay tristate.Map
+	}{
+		{"Non-pointer", widget{}, tristate.Map{"spec.suspend": tristate.New(true)}},
+		{"Unknown path", &widget{}, tristate.Map{"spec.nope": tristate.New(true)}},
+		{"Non-bool field", &widget{}, tristate.Map{"metadata": tristate.New(true)}},
+		{"Through scalar", &widget{}, tristate.Map{"spec.suspend.x": tristate.New(true)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ApplyOverlay(tt.obj, tt.overlay); err == nil {
+				t.Error("ApplyOverlay succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestApplyOverlay_Atomic(t *testing.T) {
+	overlay := tristate.Map{
+		"spec.suspend":                          tristate.New(true),
+		"spec.pod.securityContext.runAsNonRoot": tristate.New(true),
+		"spec.nope":                             tristate.New(true),
+		"spec.zzz":                              tristate.New(false),
+	}
+	for range 20 {
+		obj := &widget{}
+		err := ApplyOverlay(obj, overlay)
+		if err == nil || !strings.Contains(err.Error(), `"spec.nope"`) {
+			t.Fatalf("ApplyOverlay error = %v, want the first invalid path", err)
+		}
+		if obj.Spec.Suspend != nil || obj.Spec.Pod.SecurityContext != nil {
+			t.Fatalf("ApplyOverlay modified obj before failing: %+v", obj.Spec)
+		}
+	}
+}
+
+func TestPtrConversion(t *testing.T) {
+	if ToPtr(tristate.TriState{}) != nil || !FromPtr(nil).IsNone() {
+		t.Error("None does not map to nil")
+	}
+	if p := ToPtr(tristate.New(true)); p == nil || !*p || !FromPtr(p).IsTrue() {
+		t.Error("True does not round-trip")
+	}
+}
@@ -0,0 +1,46 @@
+// Package tristatekoanf lets knadh/koanf unmarshal merged configuration
+// into tristate.TriState fields.
+//
+// koanf merges every provider into one tree before unmarshaling, so a key
+// that no provider sets is simply absent and its field stays None, while
+// booleans and boolean strings (as produced by the env and flag providers)
+// decode to True or False:
+//
+//	var cfg struct {
+//		Beta tristate.TriState `koanf:"beta"`
+//	}
+//	err := tristatekoanf.Unmarshal(k, "", &cfg)
+package tristatekoanf
+
+import (
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/knadh/koanf/v2"
+
+	"tristate"
+)
+
+// DecoderConfig returns a mapstructure configuration matching koanf's
+// defaults, with tristate.DecodeHook installed ahead of koanf's own hooks.
+// A fresh value is returned on every call because koanf sets its Result.
+func DecoderConfig() *mapstructure.DecoderConfig {
+	return &mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			tristate.DecodeHook(),
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.TextUnmarshallerHookFunc(),
+		),
+		WeaklyTypedInput: true,
+	}
+}
+
+// UnmarshalConf returns a koanf.UnmarshalConf using DecoderConfig and the
+// given struct tag; an empty tag means koanf's default "koanf".
+func UnmarshalConf(tag string) koanf.UnmarshalConf {
+	return koanf.UnmarshalConf{Tag: tag, DecoderConfig: DecoderConfig()}
+}
+
+// Unmarshal unmarshals the configuration at path (the root if empty) into
+// out, decoding TriState fields. It is k.Unmarshal with tri-state support.
+func Unmarshal(k *koanf.Koanf, path string, out any) error {
+	return k.UnmarshalWithConf(path, out, UnmarshalConf(""))
+}
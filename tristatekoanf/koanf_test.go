@@ -0,0 +1,65 @@
+package tristatekoanf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+
+	"tristate"
+)
+
+type config struct {
+	Audit   tristate.TriState `koanf:"audit"`
+	Beta    tristate.TriState `koanf:"beta"`
+	Missing tristate.TriState `koanf:"missing"`
+	Timeout time.Duration     `koanf:"timeout"`
+	Server  struct {
+		TLS tristate.TriState `koanf:"tls"`
+	} `koanf:"server"`
+}
+
+func TestUnmarshal_MergedProviders(t *testing.T) {
+	k := koanf.New(".")
+	defaults := map[string]any{"audit": true, "beta": false, "timeout": "5s", "server.tls": true}
+	overrides := map[string]any{"beta": "true", "server.tls": "0"}
+	for _, m := range []map[string]any{defaults, overrides} {
+		if err := k.Load(confmap.Provider(m, "."), nil); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+	}
+
+	var cfg config
+	if err := Unmarshal(k, "", &cfg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		got  tristate.TriState
+		want bool
+		set  bool
+	}{
+		{"Default provider", cfg.Audit, true, true},
+		{"Later provider wins", cfg.Beta, true, true},
+		{"Absent everywhere", cfg.Missing, false, false},
+		{"Nested", cfg.Server.TLS, false, true},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.got.Bool(); got != tt.want || ok != tt.set {
+			t.Errorf("%s: Bool() = (%v, %v), want (%v, %v)", tt.name, got, ok, tt.want, tt.set)
+		}
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, koanf default hooks not applied", cfg.Timeout)
+	}
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	k := koanf.New(".")
+	k.Load(confmap.Provider(map[string]any{"audit": "sometimes"}, "."), nil)
+	var cfg config
+	if err := Unmarshal(k, "", &cfg); err == nil {
+		t.Error("Unmarshal accepted an invalid value")
+	}
+}
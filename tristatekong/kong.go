@@ -0,0 +1,58 @@
+// Package tristatekong maps command-line values onto tristate.TriState
+// fields of alecthomas/kong grammar structs.
+//
+// Kong would otherwise decode TriState through its JSON unmarshaler, which
+// rejects tokens such as "yes" or "none" and requires a value after the
+// flag. With the Option registered, a bare --audit means True,
+// --audit=false means False, and an omitted flag stays None:
+//
+//	var cli struct {
+//		Audit tristate.TriState `help:"Enable auditing." env:"APP_AUDIT"`
+//	}
+//	kong.Parse(&cli, tristatekong.Option())
+package tristatekong
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/alecthomas/kong"
+
+	"tristate"
+)
+
+// Option registers Mapper for tristate.TriState fields.
+func Option() kong.Option {
+	return kong.TypeMapper(reflect.TypeOf(tristate.TriState{}), Mapper())
+}
+
+// Mapper returns a kong.Mapper that parses values with
+// tristate.DefaultVocabulary, a superset of the words kong accepts for
+// bool flags. It reports itself as a bool mapper, so the value may be
+// omitted after the flag, in which case it decodes to True.
+func Mapper() kong.Mapper { return mapper{} }
+
+type mapper struct{}
+
+func (mapper) IsBool() bool { return true }
+
+func (mapper) Decode(ctx *kong.DecodeContext, target reflect.Value) error {
+	if ctx.Scan.Peek().Type != kong.FlagValueToken {
+		target.Set(reflect.ValueOf(tristate.New(true)))
+		return nil
+	}
+	var v tristate.TriState
+	switch tok := ctx.Scan.Pop().Value.(type) {
+	case string:
+		var err error
+		if v, err = tristate.DefaultVocabulary.Parse(tok); err != nil {
+			return err
+		}
+	case bool:
+		v = tristate.New(tok)
+	default:
+		return fmt.Errorf("expected tristate but got %q (%T)", tok, tok)
+	}
+	target.Set(reflect.ValueOf(v))
+	return nil
+}
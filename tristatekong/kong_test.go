@@ -0,0 +1,59 @@
+package tristatekong
+
+import (
+	"testing"
+
+	"github.com/alecthomas/kong"
+
+	"tristate"
+)
+
+type grammar struct {
+	Audit   tristate.TriState `help:"Enable auditing."`
+	Beta    tristate.TriState `env:"APP_BETA"`
+	Tracing tristate.TriState `default:"false"`
+}
+
+func TestOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		audit   tristate.TriState
+		beta    tristate.TriState
+		tracing tristate.TriState
+	}{
+		{"Omitted", nil, "", tristate.TriState{}, tristate.TriState{}, tristate.New(false)},
+		{"Bare", []string{"--audit"}, "", tristate.New(true), tristate.TriState{}, tristate.New(false)},
+		{"Explicit", []string{"--audit=no", "--tracing=yes"}, "", tristate.New(false), tristate.TriState{}, tristate.New(true)},
+		{"Explicit none", []string{"--tracing=none"}, "", tristate.TriState{}, tristate.TriState{}, tristate.TriState{}},
+		{"From env", nil, "true", tristate.TriState{}, tristate.New(true), tristate.New(false)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("APP_BETA", tt.env)
+			}
+			var cli grammar
+			parser, err := kong.New(&cli, Option())
+			if err != nil {
+				t.Fatalf("kong.New failed: %v", err)
+			}
+			if _, err := parser.Parse(tt.args); err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if cli.Audit != tt.audit || cli.Beta != tt.beta || cli.Tracing != tt.tracing {
+				t.Errorf("got %+v", cli)
+			}
+		})
+	}
+
+	var cli grammar
+	parser, err := kong.New(&cli, Option())
+	if err != nil {
+		t.Fatalf("kong.New failed: %v", err)
+	}
+	if _, err := parser.Parse([]string{"--audit=sometimes"}); err == nil {
+		t.Error("Parse accepted an invalid value")
+	}
+}
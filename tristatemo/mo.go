@@ -0,0 +1,48 @@
+// Package tristatemo converts between tristate values and the Option type
+// of github.com/samber/mo, so codebases standardized on samber's
+// functional libraries can adopt TriState at their boundaries.
+//
+// A present mo.Option[bool] is an explicit value and an absent one is
+// None. For samber/lo's pointer helpers no adapter is needed: lo.ToPtr,
+// lo.FromPtr, and lo.Nil pair directly with tristate.FromPtr and
+// TriState.Ptr.
+package tristatemo
+
+import (
+	"github.com/samber/mo"
+
+	"tristate"
+)
+
+// FromOption converts o to a TriState, None when o is absent.
+func FromOption(o mo.Option[bool]) tristate.TriState {
+	if b, ok := o.Get(); ok {
+		return tristate.New(b)
+	}
+	return tristate.TriState{}
+}
+
+// ToOption converts t to an Option, absent when t is None.
+func ToOption(t tristate.TriState) mo.Option[bool] {
+	if b, ok := t.Bool(); ok {
+		return mo.Some(b)
+	}
+	return mo.None[bool]()
+}
+
+// OptionalFromOption converts o to a tristate.Optional, unset when o is
+// absent.
+func OptionalFromOption[T any](o mo.Option[T]) tristate.Optional[T] {
+	if v, ok := o.Get(); ok {
+		return tristate.Of(v)
+	}
+	return tristate.Optional[T]{}
+}
+
+// OptionalToOption converts o to an Option, absent when o is unset.
+func OptionalToOption[T any](o tristate.Optional[T]) mo.Option[T] {
+	if v, ok := o.Get(); ok {
+		return mo.Some(v)
+	}
+	return mo.None[T]()
+}
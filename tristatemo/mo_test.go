@@ -0,0 +1,59 @@
+package tristatemo
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+
+	"tristate"
+)
+
+func TestOption(t *testing.T) {
+	tests := []struct {
+		name string
+		o    mo.Option[bool]
+		t    tristate.TriState
+	}{
+		{"True", mo.Some(true), tristate.New(true)},
+		{"False", mo.Some(false), tristate.New(false)},
+		{"None", mo.None[bool](), tristate.TriState{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromOption(tt.o); got != tt.t {
+				t.Errorf("FromOption() = %v, want %v", got, tt.t)
+			}
+			if got := ToOption(tt.t); got.IsPresent() != tt.o.IsPresent() || got.OrEmpty() != tt.o.OrEmpty() {
+				t.Errorf("ToOption() = %v, want %v", got, tt.o)
+			}
+		})
+	}
+}
+
+func TestOptional(t *testing.T) {
+	if got := OptionalFromOption(mo.Some("eu")); got != tristate.Of("eu") {
+		t.Errorf("OptionalFromOption(Some) = %v", got)
+	}
+	if got := OptionalFromOption(mo.None[string]()); got.IsSet() {
+		t.Errorf("OptionalFromOption(None) = %v", got)
+	}
+	if got := OptionalToOption(tristate.Of(0)); got.MustGet() != 0 {
+		t.Errorf("OptionalToOption(0) = %v", got)
+	}
+	if got := OptionalToOption(tristate.Optional[int]{}); got.IsPresent() {
+		t.Errorf("OptionalToOption(unset) = %v", got)
+	}
+}
+
+func TestLoPointers(t *testing.T) {
+	if got := tristate.FromPtr(lo.ToPtr(false)); !got.IsFalse() {
+		t.Errorf("FromPtr(lo.ToPtr(false)) = %v", got)
+	}
+	if got := tristate.FromPtr(lo.Nil[bool]()); !got.IsNone() {
+		t.Errorf("FromPtr(lo.Nil()) = %v", got)
+	}
+	if got := lo.FromPtr(tristate.New(true).Ptr()); !got {
+		t.Errorf("lo.FromPtr(True.Ptr()) = %v", got)
+	}
+}
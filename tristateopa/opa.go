@@ -0,0 +1,63 @@
+// Package tristateopa carries tristate values across the Open Policy Agent
+// boundary, keeping None as Rego's "undefined" in both directions.
+//
+// Input builds an input document from a tristate.Map, leaving None keys
+// out so input.flag is undefined in Rego rather than false. FromResultSet
+// reads a decision back: an undefined result is None, true and false are
+// True and False.
+//
+//	q, _ := rego.New(rego.Query("data.authz.allow"), rego.Module("authz.rego", src)).PrepareForEval(ctx)
+//	decision, err := tristateopa.Eval(ctx, q, flags)
+package tristateopa
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+
+	"tristate"
+)
+
+// Input returns m as a Rego input document mapping each explicit key to a
+// boolean. None keys are omitted, so they are undefined in the policy.
+func Input(m tristate.Map) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if b, ok := v.Bool(); ok {
+			out[k] = b
+		}
+	}
+	return out
+}
+
+// FromResultSet interprets the result of a single-expression query. An
+// empty result set (the rule is undefined) and a null value are None; a
+// boolean is True or False. Any other value, or more than one result or
+// expression, is an error.
+func FromResultSet(rs rego.ResultSet) (tristate.TriState, error) {
+	switch {
+	case len(rs) == 0:
+		return tristate.TriState{}, nil
+	case len(rs) > 1 || len(rs[0].Expressions) != 1:
+		return tristate.TriState{}, fmt.Errorf("tristateopa: rego query has %d results with %d expressions, want one of each", len(rs), len(rs[0].Expressions))
+	}
+	switch v := rs[0].Expressions[0].Value.(type) {
+	case nil:
+		return tristate.TriState{}, nil
+	case bool:
+		return tristate.New(v), nil
+	default:
+		return tristate.TriState{}, fmt.Errorf("tristateopa: rego expression %s is %T, want boolean", rs[0].Expressions[0].Text, v)
+	}
+}
+
+// Eval evaluates q with Input(input) and returns the decision as
+// FromResultSet does.
+func Eval(ctx context.Context, q rego.PreparedEvalQuery, input tristate.Map) (tristate.TriState, error) {
+	rs, err := q.Eval(ctx, rego.EvalInput(Input(input)))
+	if err != nil {
+		return tristate.TriState{}, err
+	}
+	return FromResultSet(rs)
+}
@@ -0,0 +1,69 @@
+package tristateopa
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+
+	"tristate"
+)
+
+const module = `package authz
+
+# Undefined unless the request says something about admin.
+allow if input.admin
+allow := false if input.admin == false
+
+label := "x" if input.label
+`
+
+func prepare(t *testing.T, query string) rego.PreparedEvalQuery {
+	t.Helper()
+	q, err := rego.New(rego.Query(query), rego.Module("authz.rego", module)).PrepareForEval(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestInput(t *testing.T) {
+	got := Input(tristate.Map{"a": tristate.New(true), "b": tristate.New(false), "c": {}})
+	if len(got) != 2 || got["a"] != true || got["b"] != false {
+		t.Errorf("Input() = %v", got)
+	}
+}
+
+func TestEval(t *testing.T) {
+	q := prepare(t, "data.authz.allow")
+	tests := []struct {
+		name  string
+		input tristate.Map
+		want  tristate.TriState
+	}{
+		{"Allow", tristate.Map{"admin": tristate.New(true)}, tristate.New(true)},
+		{"Deny", tristate.Map{"admin": tristate.New(false)}, tristate.New(false)},
+		{"Undefined", tristate.Map{"admin": {}}, tristate.TriState{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Eval(context.Background(), q, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Eval(context.Background(), prepare(t, "data.authz.label"), tristate.Map{"label": tristate.New(true)}); err == nil {
+		t.Error("Eval() of a string decision succeeded, want error")
+	}
+
+	_, err := Eval(context.Background(), prepare(t, "data.authz.allow; data.authz.allow"), tristate.Map{"admin": tristate.New(true)})
+	if err == nil || !strings.Contains(err.Error(), "1 results with 2 expressions") {
+		t.Errorf("Eval() of a two-expression query = %v, want expression count error", err)
+	}
+}
@@ -0,0 +1,44 @@
+// Package tristateopenapi describes tristate.TriState fields correctly in
+// OpenAPI documents generated by getkin/kin-openapi.
+//
+// openapi3gen reflects TriState as an object with no properties. With the
+// Customizer option it becomes a nullable boolean instead, matching
+// TriState.Schema:
+//
+//	ref, err := openapi3gen.NewSchemaRefForValue(&Request{}, nil, tristateopenapi.Customizer())
+//
+// Documents built with swaggest/openapi-go need no option: TriState
+// implements swaggest's RawExposer directly.
+package tristateopenapi
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+
+	"tristate"
+)
+
+var triStateType = reflect.TypeFor[tristate.TriState]()
+
+// Customizer returns an openapi3gen option rewriting the schema of every
+// TriState to a nullable boolean.
+func Customizer() openapi3gen.Option {
+	return openapi3gen.SchemaCustomizer(Customize)
+}
+
+// Customize is the openapi3gen.SchemaCustomizerFn behind Customizer, for
+// callers that need to combine it with their own customizer.
+func Customize(_ string, t reflect.Type, _ reflect.StructTag, schema *openapi3.Schema) error {
+	if t != triStateType {
+		return nil
+	}
+	s := tristate.TriState{}.Schema()
+	*schema = openapi3.Schema{
+		Type:        &openapi3.Types{openapi3.TypeBoolean},
+		Nullable:    true,
+		Description: s["description"].(string),
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package tristateopenapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/swaggest/openapi-go/openapi3"
+
+	"tristate"
+)
+
+type request struct {
+	Archived tristate.TriState  `json:"archived"`
+	Pinned   *tristate.TriState `json:"pinned,omitempty"`
+	Name     string             `json:"name"`
+}
+
+func TestCustomizer(t *testing.T) {
+	ref, err := openapi3gen.NewSchemaRefForValue(&request{}, nil, Customizer())
+	if err != nil {
+		t.Fatalf("NewSchemaRefForValue failed: %v", err)
+	}
+	for _, name := range []string{"archived", "pinned"} {
+		prop := ref.Value.Properties[name]
+		if prop == nil {
+			t.Fatalf("missing property %q", name)
+		}
+		s := prop.Value
+		if !s.Type.Is("boolean") || !s.Nullable || s.Description == "" || len(s.Properties) != 0 {
+			t.Errorf("%s schema = %+v", name, s)
+		}
+	}
+	if !ref.Value.Properties["name"].Value.Type.Is("string") {
+		t.Error("Customizer changed a non-TriState field")
+	}
+}
+
+func TestSwaggestReflector(t *testing.T) {
+	r := openapi3.Reflector{}
+	s, err := r.Reflect(request{})
+	if err != nil {
+		t.Fatalf("Reflect failed: %v", err)
+	}
+	var oas openapi3.SchemaOrRef
+	oas.FromJSONSchema(s.ToSchemaOrBool())
+	data, err := json.Marshal(oas.Schema.Properties["archived"])
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got["type"] != "boolean" || got["nullable"] != true || got["description"] == nil {
+		t.Errorf("archived schema = %s", data)
+	}
+}
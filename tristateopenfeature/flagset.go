@@ -0,0 +1,107 @@
+package tristateopenfeature
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/open-feature/go-sdk/openfeature"
+
+	"tristate/featureflag"
+)
+
+// Evaluation context attributes read by SetProvider, besides the
+// targeting key, which names the user.
+const (
+	TenantAttribute      = "tenant"
+	EnvironmentAttribute = "environment"
+)
+
+// SetProvider is an openfeature.FeatureProvider backed by a
+// featureflag.Set.
+type SetProvider struct {
+	set *featureflag.Set
+}
+
+var _ openfeature.FeatureProvider = (*SetProvider)(nil)
+
+// NewSetProvider returns a SetProvider evaluating flags in s.
+func NewSetProvider(s *featureflag.Set) *SetProvider {
+	return &SetProvider{set: s}
+}
+
+// Metadata implements openfeature.FeatureProvider.
+func (p *SetProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: Name}
+}
+
+// Hooks implements openfeature.FeatureProvider.
+func (p *SetProvider) Hooks() []openfeature.Hook { return nil }
+
+// BooleanEvaluation evaluates flag for the featureflag.Context built from
+// flatCtx: the targeting key is the user, and the TenantAttribute and
+// EnvironmentAttribute strings are the tenant and environment. A value
+// decided by an override is reported with reason TARGETING_MATCH and the
+// target in the "target" metadata entry, one decided by the flag's
+// rollout with reason SPLIT, and the flag's default with reason DEFAULT.
+// An undefined flag resolves to defaultValue with FLAG_NOT_FOUND.
+func (p *SetProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, flatCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	r, err := p.set.Detail(evaluationContext(flatCtx), flag)
+	if err != nil {
+		resErr := openfeature.NewGeneralResolutionError(err.Error())
+		if errors.Is(err, featureflag.ErrUnknownFlag) {
+			resErr = openfeature.NewFlagNotFoundResolutionError(err.Error())
+		}
+		return openfeature.BoolResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: resErr,
+				Reason:          openfeature.ErrorReason,
+			},
+		}
+	}
+	detail := openfeature.ProviderResolutionDetail{Reason: openfeature.DefaultReason, Variant: strconv.FormatBool(r.Value)}
+	switch {
+	case r.Rollout:
+		detail.Reason = openfeature.SplitReason
+	case !r.IsDefault():
+		detail.Reason = openfeature.TargetingMatchReason
+		detail.FlagMetadata = openfeature.FlagMetadata{"target": r.Target.Kind.String() + ":" + r.Target.ID}
+	}
+	return openfeature.BoolResolutionDetail{Value: r.Value, ProviderResolutionDetail: detail}
+}
+
+// evaluationContext maps an OpenFeature evaluation context onto the
+// targets featureflag understands. Attributes that are not strings are
+// ignored.
+func evaluationContext(flatCtx openfeature.FlattenedContext) featureflag.Context {
+	str := func(key string) string {
+		s, _ := flatCtx[key].(string)
+		return s
+	}
+	return featureflag.Context{
+		User:        str(openfeature.TargetingKey),
+		Tenant:      str(TenantAttribute),
+		Environment: str(EnvironmentAttribute),
+	}
+}
+
+// StringEvaluation reports a type mismatch; feature flags are boolean.
+func (p *SetProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, _ openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
+
+// FloatEvaluation reports a type mismatch; feature flags are boolean.
+func (p *SetProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, _ openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
+
+// IntEvaluation reports a type mismatch; feature flags are boolean.
+func (p *SetProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, _ openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
+
+// ObjectEvaluation reports a type mismatch; feature flags are boolean.
+func (p *SetProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue any, _ openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
@@ -0,0 +1,62 @@
+package tristateopenfeature
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+
+	"tristate"
+	"tristate/featureflag"
+)
+
+func TestSetProvider(t *testing.T) {
+	s := featureflag.New(
+		featureflag.Flag{Name: "checkout", Default: false},
+		featureflag.Flag{Name: "search", Rollout: 100},
+	)
+	s.Override("checkout", featureflag.Tenant("acme"), tristate.New(true))
+	s.Override("checkout", featureflag.User("alice"), tristate.New(false))
+	s.Override("checkout", featureflag.Environment("staging"), tristate.New(true))
+
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), NewSetProvider(s)); err != nil {
+		t.Fatal(err)
+	}
+	client := openfeature.NewClient(t.Name())
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		flag       string
+		evalCtx    openfeature.EvaluationContext
+		def        bool
+		want       bool
+		wantReason openfeature.Reason
+		wantTarget string
+		wantErr    openfeature.ErrorCode
+	}{
+		{"Default", "checkout", openfeature.NewEvaluationContext("bob", nil), true, false, openfeature.DefaultReason, "", ""},
+		{"Tenant override", "checkout", openfeature.NewEvaluationContext("bob", map[string]any{TenantAttribute: "acme"}),
+			false, true, openfeature.TargetingMatchReason, "tenant:acme", ""},
+		{"User beats tenant", "checkout", openfeature.NewEvaluationContext("alice", map[string]any{TenantAttribute: "acme"}),
+			true, false, openfeature.TargetingMatchReason, "user:alice", ""},
+		{"Environment", "checkout", openfeature.NewTargetlessEvaluationContext(map[string]any{EnvironmentAttribute: "staging"}),
+			false, true, openfeature.TargetingMatchReason, "environment:staging", ""},
+		{"Rollout", "search", openfeature.NewEvaluationContext("bob", nil), false, true, openfeature.SplitReason, "", ""},
+		{"Unknown flag", "missing", openfeature.NewEvaluationContext("bob", nil), true, true, openfeature.ErrorReason, "", openfeature.FlagNotFoundCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := client.BooleanValueDetails(ctx, tt.flag, tt.def, tt.evalCtx)
+			if (err != nil) != (tt.wantErr != "") || d.ErrorCode != tt.wantErr {
+				t.Fatalf("BooleanValueDetails() error = %v (%s), want %s", err, d.ErrorCode, tt.wantErr)
+			}
+			if d.Value != tt.want || d.Reason != tt.wantReason {
+				t.Errorf("BooleanValueDetails() = %v (%s), want %v (%s)", d.Value, d.Reason, tt.want, tt.wantReason)
+			}
+			if target, _ := d.FlagMetadata.GetString("target"); target != tt.wantTarget {
+				t.Errorf("target = %q, want %q", target, tt.wantTarget)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Package tristateopenfeature serves boolean flags through the OpenFeature
+// API, so services written against OpenFeature can source flags from this
+// module.
+//
+// Provider resolves flags from a tristate.Resolver's layered
+// configuration. A flag no source sets resolves to the default passed to
+// the SDK, and the evaluation context is ignored:
+//
+//	r := tristate.NewResolver(tristate.EnvSource("FEATURE_"), tristate.MapSource("file", m))
+//	openfeature.SetProviderAndWait(tristateopenfeature.NewProvider(r))
+//	on, _ := openfeature.NewDefaultClient().BooleanValue(ctx, "dark_mode", false, openfeature.EvaluationContext{})
+//
+// SetProvider evaluates a featureflag.Set for the user, tenant, and
+// environment of the evaluation context, so per-target overrides and
+// percentage rollouts apply. Both support only boolean flags.
+package tristateopenfeature
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/open-feature/go-sdk/openfeature"
+
+	"tristate"
+)
+
+// Name is the provider name reported in Metadata.
+const Name = "tristate"
+
+// Provider is an openfeature.FeatureProvider backed by a tristate.Resolver.
+type Provider struct {
+	resolver *tristate.Resolver
+}
+
+var _ openfeature.FeatureProvider = (*Provider)(nil)
+
+// NewProvider returns a Provider resolving flags with r.
+func NewProvider(r *tristate.Resolver) *Provider {
+	return &Provider{resolver: r}
+}
+
+// Metadata implements openfeature.FeatureProvider.
+func (p *Provider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: Name}
+}
+
+// Hooks implements openfeature.FeatureProvider.
+func (p *Provider) Hooks() []openfeature.Hook { return nil }
+
+// BooleanEvaluation resolves flag. An explicit value is reported with
+// reason STATIC, its variant "true" or "false", and the name of the source
+// that set it in the "source" metadata entry. None resolves to
+// defaultValue with reason DEFAULT, and a source error to defaultValue
+// with reason ERROR.
+func (p *Provider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, _ openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	v, source, err := p.resolver.Lookup(flag)
+	if err != nil {
+		return openfeature.BoolResolutionDetail{
+			Value: defaultValue,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewGeneralResolutionError(err.Error()),
+				Reason:          openfeature.ErrorReason,
+			},
+		}
+	}
+	b, ok := v.Bool()
+	if !ok {
+		return openfeature.BoolResolutionDetail{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{Reason: openfeature.DefaultReason},
+		}
+	}
+	return openfeature.BoolResolutionDetail{
+		Value: b,
+		ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+			Reason:       openfeature.StaticReason,
+			Variant:      strconv.FormatBool(b),
+			FlagMetadata: openfeature.FlagMetadata{"source": source},
+		},
+	}
+}
+
+// StringEvaluation reports a type mismatch; tristate flags are boolean.
+func (p *Provider) StringEvaluation(ctx context.Context, flag string, defaultValue string, _ openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
+
+// FloatEvaluation reports a type mismatch; tristate flags are boolean.
+func (p *Provider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, _ openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
+
+// IntEvaluation reports a type mismatch; tristate flags are boolean.
+func (p *Provider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, _ openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
+
+// ObjectEvaluation reports a type mismatch; tristate flags are boolean.
+func (p *Provider) ObjectEvaluation(ctx context.Context, flag string, defaultValue any, _ openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: mismatch(flag)}
+}
+
+func mismatch(flag string) openfeature.ProviderResolutionDetail {
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewTypeMismatchResolutionError("tristateopenfeature: flag " + strconv.Quote(flag) + " is boolean"),
+		Reason:          openfeature.ErrorReason,
+	}
+}
@@ -0,0 +1,62 @@
+package tristateopenfeature
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+
+	"tristate"
+)
+
+func TestProvider(t *testing.T) {
+	r := tristate.NewResolver(
+		tristate.MapSource("file", tristate.Map{"dark_mode": tristate.New(true), "audit": tristate.New(false)}),
+		tristate.SourceFunc("remote", func(key string) (tristate.TriState, error) {
+			if key == "broken" {
+				return tristate.TriState{}, errors.New("timeout")
+			}
+			return tristate.TriState{}, nil
+		}),
+	)
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), NewProvider(r)); err != nil {
+		t.Fatal(err)
+	}
+	client := openfeature.NewClient(t.Name())
+	ctx := context.Background()
+
+	tests := []struct {
+		flag       string
+		def        bool
+		want       bool
+		wantReason openfeature.Reason
+		wantErr    bool
+	}{
+		{"dark_mode", false, true, openfeature.StaticReason, false},
+		{"audit", true, false, openfeature.StaticReason, false},
+		{"unset", true, true, openfeature.DefaultReason, false},
+		{"unset", false, false, openfeature.DefaultReason, false},
+		{"broken", true, true, openfeature.ErrorReason, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			d, err := client.BooleanValueDetails(ctx, tt.flag, tt.def, openfeature.EvaluationContext{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BooleanValueDetails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if d.Value != tt.want || d.Reason != tt.wantReason {
+				t.Errorf("BooleanValueDetails() = %v (%s), want %v (%s)", d.Value, d.Reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+
+	d, _ := client.BooleanValueDetails(ctx, "dark_mode", false, openfeature.EvaluationContext{})
+	if src, _ := d.FlagMetadata.GetString("source"); src != "file" || d.Variant != "true" {
+		t.Errorf("source = %q, variant = %q", src, d.Variant)
+	}
+
+	if s, err := client.StringValue(ctx, "dark_mode", "x", openfeature.EvaluationContext{}); err == nil || s != "x" {
+		t.Errorf("StringValue() = %q, %v; want default and a type mismatch", s, err)
+	}
+}
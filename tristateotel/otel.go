@@ -0,0 +1,50 @@
+// Package tristateotel turns tristate values into OpenTelemetry
+// attributes, so spans and metrics carry flag state the same way in every
+// service.
+//
+// Two encodings are offered. String produces a single string attribute,
+// "true", "false", or "none", which is easiest to group by. Pair produces a
+// bool attribute plus a "<key>.set" presence attribute, for backends that
+// filter better on booleans:
+//
+//	span.SetAttributes(tristateotel.String("flag.dark_mode", v))
+package tristateotel
+
+import (
+	"cmp"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"tristate"
+)
+
+// String returns key set to "true", "false", or "none".
+func String(key string, v tristate.TriState) attribute.KeyValue {
+	return attribute.String(key, v.String())
+}
+
+// Pair returns key set to the value and key+".set" reporting whether it
+// is explicit. For None only the presence attribute is returned, as an
+// unset value has no bool to report.
+func Pair(key string, v tristate.TriState) []attribute.KeyValue {
+	b, ok := v.Bool()
+	set := attribute.Bool(key+".set", ok)
+	if !ok {
+		return []attribute.KeyValue{set}
+	}
+	return []attribute.KeyValue{attribute.Bool(key, b), set}
+}
+
+// Map returns a String attribute for every entry of m, keyed by prefix
+// followed by the entry's key and sorted by key.
+func Map(prefix string, m tristate.Map) []attribute.KeyValue {
+	out := make([]attribute.KeyValue, 0, len(m))
+	for k, v := range m {
+		out = append(out, String(prefix+k, v))
+	}
+	slices.SortFunc(out, func(a, b attribute.KeyValue) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return out
+}
@@ -0,0 +1,50 @@
+package tristateotel
+
+import (
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"tristate"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		v    tristate.TriState
+		want string
+	}{
+		{tristate.New(true), "true"},
+		{tristate.New(false), "false"},
+		{tristate.TriState{}, "none"},
+	}
+	for _, tt := range tests {
+		if got := String("flag", tt.v); got != attribute.String("flag", tt.want) {
+			t.Errorf("String(%v) = %v", tt.v, got)
+		}
+	}
+}
+
+func TestPair(t *testing.T) {
+	tests := []struct {
+		v    tristate.TriState
+		want []attribute.KeyValue
+	}{
+		{tristate.New(true), []attribute.KeyValue{attribute.Bool("flag", true), attribute.Bool("flag.set", true)}},
+		{tristate.New(false), []attribute.KeyValue{attribute.Bool("flag", false), attribute.Bool("flag.set", true)}},
+		{tristate.TriState{}, []attribute.KeyValue{attribute.Bool("flag.set", false)}},
+	}
+	for _, tt := range tests {
+		if got := Pair("flag", tt.v); !slices.Equal(got, tt.want) {
+			t.Errorf("Pair(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	got := Map("flag.", tristate.Map{"beta": tristate.New(false), "audit": tristate.New(true)})
+	want := []attribute.KeyValue{attribute.String("flag.audit", "true"), attribute.String("flag.beta", "false")}
+	if !slices.Equal(got, want) {
+		t.Errorf("Map() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package tristatepb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"tristate"
+	"tristate/tristatepb/internal/testpb"
+)
+
+// echoServer records the request the gateway transcoded and echoes it.
+type echoServer struct {
+	testpb.UnimplementedSettingsServiceServer
+	got *testpb.Settings
+}
+
+func (s *echoServer) GetSettings(_ context.Context, req *testpb.Settings) (*testpb.Settings, error) {
+	s.got = req
+	return req, nil
+}
+
+func (s *echoServer) UpdateSettings(_ context.Context, req *testpb.Settings) (*testpb.Settings, error) {
+	s.got = req
+	return req, nil
+}
+
+func newGateway(t *testing.T) (*runtime.ServeMux, *echoServer) {
+	t.Helper()
+	srv := &echoServer{}
+	mux := runtime.NewServeMux(runtime.SetQueryParameterParser(&QueryParser{}))
+	if err := testpb.RegisterSettingsServiceHandlerServer(context.Background(), mux, srv); err != nil {
+		t.Fatal(err)
+	}
+	return mux, srv
+}
+
+func triStates(t *testing.T, m *testpb.Settings) (audit, beta tristate.TriState) {
+	t.Helper()
+	audit, err := Get(m, "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	beta, err = Get(m, "beta_mode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if audit != tristate.FromPtr(m.Audit) || beta != FromBoolValue(m.BetaMode) {
+		t.Errorf("Get disagrees with the generated fields: %v", m)
+	}
+	return audit, beta
+}
+
+func TestGateway_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantAudit tristate.TriState
+		wantBeta  tristate.TriState
+		// The gateway emits unpopulated fields: an unset optional bool is
+		// omitted and an unset BoolValue is null.
+		wantBody string
+	}{
+		{"Unset", "", tristate.TriState{}, tristate.TriState{}, `{"betaMode":null,"name":""}`},
+		{"Vocabulary", "audit=yes&beta_mode=off", tristate.New(true), tristate.New(false), `{"audit":true,"betaMode":false,"name":""}`},
+		{"JSON name", "betaMode=on", tristate.TriState{}, tristate.New(true), `{"betaMode":true,"name":""}`},
+		{"Empty is None", "audit=&beta_mode=", tristate.TriState{}, tristate.TriState{}, `{"betaMode":null,"name":""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, srv := newGateway(t)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/settings?"+tt.query, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status %d: %s", rec.Code, rec.Body)
+			}
+			audit, beta := triStates(t, srv.got)
+			if audit != tt.wantAudit || beta != tt.wantBeta {
+				t.Errorf("transcoded audit=%v beta=%v, want %v %v", audit, beta, tt.wantAudit, tt.wantBeta)
+			}
+			if got := strings.ReplaceAll(rec.Body.String(), " ", ""); got != tt.wantBody {
+				t.Errorf("response body = %s, want %s", got, tt.wantBody)
+			}
+		})
+	}
+
+	mux, _ := newGateway(t)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/settings?audit=maybe", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid token: status %d, want 400", rec.Code)
+	}
+}
+
+func TestGateway_Body(t *testing.T) {
+	mux, srv := newGateway(t)
+	rec := httptest.NewRecorder()
+	body := `{"audit":false,"betaMode":null,"name":"x"}`
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/settings", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	audit, beta := triStates(t, srv.got)
+	if audit != tristate.New(false) || !beta.IsNone() {
+		t.Errorf("transcoded audit=%v beta=%v, want false and None", audit, beta)
+	}
+}
@@ -0,0 +1,6 @@
+// Package testpb holds a generated message, gRPC service, and
+// grpc-gateway stub for testing tristatepb end to end. The HTTP bindings
+// are in settings.yaml, so the proto needs no google.api annotations.
+package testpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative --grpc-gateway_out=. --grpc-gateway_opt=paths=source_relative,grpc_api_configuration=settings.yaml settings.proto
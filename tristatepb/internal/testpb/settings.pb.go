@@ -0,0 +1,153 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: settings.proto
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Settings carries both encodings of an optional boolean.
+type Settings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Audit         *bool                  `protobuf:"varint,1,opt,name=audit,proto3,oneof" json:"audit,omitempty"`
+	BetaMode      *wrapperspb.BoolValue  `protobuf:"bytes,2,opt,name=beta_mode,json=betaMode,proto3" json:"beta_mode,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Settings) Reset() {
+	*x = Settings{}
+	mi := &file_settings_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Settings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Settings) ProtoMessage() {}
+
+func (x *Settings) ProtoReflect() protoreflect.Message {
+	mi := &file_settings_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Settings.ProtoReflect.Descriptor instead.
+func (*Settings) Descriptor() ([]byte, []int) {
+	return file_settings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Settings) GetAudit() bool {
+	if x != nil && x.Audit != nil {
+		return *x.Audit
+	}
+	return false
+}
+
+func (x *Settings) GetBetaMode() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.BetaMode
+	}
+	return nil
+}
+
+func (x *Settings) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_settings_proto protoreflect.FileDescriptor
+
+const file_settings_proto_rawDesc = "" +
+	"\n" +
+	"\x0esettings.proto\x12\x0ftristatepb.test\x1a\x1egoogle/protobuf/wrappers.proto\"|\n" +
+	"\bSettings\x12\x19\n" +
+	"\x05audit\x18\x01 \x01(\bH\x00R\x05audit\x88\x01\x01\x127\n" +
+	"\tbeta_mode\x18\x02 \x01(\v2\x1a.google.protobuf.BoolValueR\bbetaMode\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04nameB\b\n" +
+	"\x06_audit2\x9e\x01\n" +
+	"\x0fSettingsService\x12C\n" +
+	"\vGetSettings\x12\x19.tristatepb.test.Settings\x1a\x19.tristatepb.test.Settings\x12F\n" +
+	"\x0eUpdateSettings\x12\x19.tristatepb.test.Settings\x1a\x19.tristatepb.test.SettingsB,Z*tristate/tristatepb/internal/testpb;testpbb\x06proto3"
+
+var (
+	file_settings_proto_rawDescOnce sync.Once
+	file_settings_proto_rawDescData []byte
+)
+
+func file_settings_proto_rawDescGZIP() []byte {
+	file_settings_proto_rawDescOnce.Do(func() {
+		file_settings_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_settings_proto_rawDesc), len(file_settings_proto_rawDesc)))
+	})
+	return file_settings_proto_rawDescData
+}
+
+var file_settings_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_settings_proto_goTypes = []any{
+	(*Settings)(nil),             // 0: tristatepb.test.Settings
+	(*wrapperspb.BoolValue)(nil), // 1: google.protobuf.BoolValue
+}
+var file_settings_proto_depIdxs = []int32{
+	1, // 0: tristatepb.test.Settings.beta_mode:type_name -> google.protobuf.BoolValue
+	0, // 1: tristatepb.test.SettingsService.GetSettings:input_type -> tristatepb.test.Settings
+	0, // 2: tristatepb.test.SettingsService.UpdateSettings:input_type -> tristatepb.test.Settings
+	0, // 3: tristatepb.test.SettingsService.GetSettings:output_type -> tristatepb.test.Settings
+	0, // 4: tristatepb.test.SettingsService.UpdateSettings:output_type -> tristatepb.test.Settings
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_settings_proto_init() }
+func file_settings_proto_init() {
+	if File_settings_proto != nil {
+		return
+	}
+	file_settings_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_settings_proto_rawDesc), len(file_settings_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_settings_proto_goTypes,
+		DependencyIndexes: file_settings_proto_depIdxs,
+		MessageInfos:      file_settings_proto_msgTypes,
+	}.Build()
+	File_settings_proto = out.File
+	file_settings_proto_goTypes = nil
+	file_settings_proto_depIdxs = nil
+}
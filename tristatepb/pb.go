@@ -0,0 +1,163 @@
+// Package tristatepb converts between tristate.TriState and the two
+// protobuf encodings of an optional boolean: a field declared `optional
+// bool`, whose generated Go type is *bool, and a google.protobuf.BoolValue
+// wrapper. Both have explicit presence, so None survives as "unset".
+//
+// TriState marshals to JSON as true, false, or null, which protojson and
+// grpc-gateway transcoding accept for either encoding, null meaning unset.
+// For query strings, QueryParser extends grpc-gateway's default parser
+// with the same tokens as tristate.DefaultVocabulary:
+//
+//	mux := runtime.NewServeMux(runtime.SetQueryParameterParser(&tristatepb.QueryParser{}))
+package tristatepb
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"tristate"
+)
+
+const boolValueName = "google.protobuf.BoolValue"
+
+// FromBoolValue converts a BoolValue wrapper to a TriState, mapping nil to
+// None.
+func FromBoolValue(v *wrapperspb.BoolValue) tristate.TriState {
+	if v == nil {
+		return tristate.TriState{}
+	}
+	return tristate.New(v.GetValue())
+}
+
+// ToBoolValue converts t to a BoolValue wrapper, returning nil for None.
+func ToBoolValue(t tristate.TriState) *wrapperspb.BoolValue {
+	v, ok := t.Bool()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Bool(v)
+}
+
+// Get reads the named field of m, which must be an optional bool or a
+// BoolValue. An unset field is None.
+func Get(m proto.Message, name string) (tristate.TriState, error) {
+	msg := m.ProtoReflect()
+	fd, err := field(msg.Descriptor(), name)
+	if err != nil || !msg.Has(fd) {
+		return tristate.TriState{}, err
+	}
+	v := msg.Get(fd)
+	if fd.Kind() == protoreflect.BoolKind {
+		return tristate.New(v.Bool()), nil
+	}
+	inner := v.Message()
+	return tristate.New(inner.Get(inner.Descriptor().Fields().ByName("value")).Bool()), nil
+}
+
+// Set stores t in the named field of m, which must be an optional bool or
+// a BoolValue. None clears the field.
+func Set(m proto.Message, name string, t tristate.TriState) error {
+	msg := m.ProtoReflect()
+	fd, err := field(msg.Descriptor(), name)
+	if err != nil {
+		return err
+	}
+	b, ok := t.Bool()
+	switch {
+	case !ok:
+		msg.Clear(fd)
+	case fd.Kind() == protoreflect.BoolKind:
+		msg.Set(fd, protoreflect.ValueOfBool(b))
+	default:
+		inner := msg.NewField(fd).Message()
+		inner.Set(inner.Descriptor().Fields().ByName("value"), protoreflect.ValueOfBool(b))
+		msg.Set(fd, protoreflect.ValueOfMessage(inner))
+	}
+	return nil
+}
+
+// field looks up a tri-state field of md by its proto or JSON name.
+func field(md protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, error) {
+	fd := md.Fields().ByTextName(name)
+	if fd == nil {
+		fd = md.Fields().ByJSONName(name)
+	}
+	if fd == nil {
+		return nil, fmt.Errorf("tristatepb: %s has no field %q", md.FullName(), name)
+	}
+	if !isTriState(fd) {
+		return nil, fmt.Errorf("tristatepb: field %s is not an optional bool or BoolValue", fd.FullName())
+	}
+	return fd, nil
+}
+
+func isTriState(fd protoreflect.FieldDescriptor) bool {
+	if fd.IsList() || fd.IsMap() {
+		return false
+	}
+	if fd.Kind() == protoreflect.BoolKind {
+		return fd.HasPresence()
+	}
+	return fd.Message() != nil && fd.Message().FullName() == boolValueName
+}
+
+// QueryParser is a grpc-gateway runtime.QueryParameterParser. For query
+// parameters addressing an optional bool or BoolValue field, it accepts
+// every token of tristate.DefaultVocabulary and leaves the field unset
+// for None tokens, including an empty value such as ?audit=, which the
+// default parser rejects. All parameters are then populated by
+// runtime.DefaultQueryParser.
+type QueryParser struct{}
+
+// Parse normalizes tri-state parameters and populates msg from values.
+func (*QueryParser) Parse(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
+	md := msg.ProtoReflect().Descriptor()
+	out := make(url.Values, len(values))
+	for key, vals := range values {
+		if fd := lookupPath(md, key); fd == nil || !isTriState(fd) {
+			out[key] = vals
+			continue
+		}
+		var norm []string
+		for _, raw := range vals {
+			v, err := tristate.DefaultVocabulary.Parse(raw)
+			if err != nil {
+				return fmt.Errorf("tristatepb: query parameter %q: %w", key, err)
+			}
+			if !v.IsNone() {
+				norm = append(norm, v.String())
+			}
+		}
+		if len(norm) > 0 {
+			out[key] = norm
+		}
+	}
+	return (&runtime.DefaultQueryParser{}).Parse(msg, out, filter)
+}
+
+// lookupPath resolves a dotted query key through singular message fields,
+// matching proto or JSON names as grpc-gateway does. It returns nil if the
+// key does not resolve.
+func lookupPath(md protoreflect.MessageDescriptor, key string) protoreflect.FieldDescriptor {
+	var fd protoreflect.FieldDescriptor
+	for part := range strings.SplitSeq(key, ".") {
+		if md == nil {
+			return nil
+		}
+		if fd = md.Fields().ByTextName(part); fd == nil {
+			fd = md.Fields().ByJSONName(part)
+		}
+		if fd == nil || fd.IsList() || fd.IsMap() {
+			return nil
+		}
+		md = fd.Message()
+	}
+	return fd
+}
@@ -0,0 +1,168 @@
+package tristatepb
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"tristate"
+)
+
+// settingsDescriptor builds the equivalent of
+//
+//	syntax = "proto2";
+//	message Settings {
+//	  optional bool audit = 1;
+//	  optional google.protobuf.BoolValue beta_mode = 2;
+//	  optional string name = 3;
+//	  optional Settings nested = 4;
+//	}
+func settingsDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("tristatepb_test.proto"),
+		Package:    proto.String("tristatepb.test"),
+		Syntax:     proto.String("proto2"),
+		Dependency: []string{"google/protobuf/wrappers.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Settings"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("audit"), JsonName: proto.String("audit"), Number: proto.Int32(1), Label: optional,
+					Type: descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum()},
+				{Name: proto.String("beta_mode"), JsonName: proto.String("betaMode"), Number: proto.Int32(2), Label: optional,
+					Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".google.protobuf.BoolValue")},
+				{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(3), Label: optional,
+					Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+				{Name: proto.String("nested"), JsonName: proto.String("nested"), Number: proto.Int32(4), Label: optional,
+					Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".tristatepb.test.Settings")},
+			},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("NewFile failed: %v", err)
+	}
+	return fd.Messages().Get(0)
+}
+
+func TestBoolValue(t *testing.T) {
+	for _, v := range []tristate.TriState{{}, tristate.New(true), tristate.New(false)} {
+		if got := FromBoolValue(ToBoolValue(v)); got != v {
+			t.Errorf("round trip of %v = %v", v, got)
+		}
+	}
+	if ToBoolValue(tristate.TriState{}) != nil {
+		t.Error("ToBoolValue(None) is not nil")
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	m := dynamicpb.NewMessage(settingsDescriptor(t))
+	for _, name := range []string{"audit", "beta_mode", "betaMode"} {
+		for _, v := range []tristate.TriState{tristate.New(false), tristate.New(true), {}} {
+			if err := Set(m, name, v); err != nil {
+				t.Fatalf("Set(%s) failed: %v", name, err)
+			}
+			got, err := Get(m, name)
+			if err != nil || got != v {
+				t.Errorf("Get(%s) = %v, %v; want %v", name, got, err, v)
+			}
+		}
+	}
+	if err := Set(m, "name", tristate.New(true)); err == nil {
+		t.Error("Set accepted a string field")
+	}
+	if _, err := Get(m, "missing"); err == nil {
+		t.Error("Get accepted an unknown field")
+	}
+
+	// Generated messages work the same: FieldOptions.deprecated is a
+	// proto2 optional bool.
+	opts := &descriptorpb.FieldOptions{}
+	if err := Set(opts, "deprecated", tristate.New(false)); err != nil || opts.Deprecated == nil || *opts.Deprecated {
+		t.Errorf("Set(FieldOptions.deprecated) = %v, field %v", err, opts.Deprecated)
+	}
+}
+
+func TestQueryParser(t *testing.T) {
+	md := settingsDescriptor(t)
+	tests := []struct {
+		name        string
+		query       string
+		audit, beta tristate.TriState
+		nested      tristate.TriState
+		wantErr     bool
+	}{
+		{"Absent", "name=x", tristate.TriState{}, tristate.TriState{}, tristate.TriState{}, false},
+		{"Explicit", "audit=yes&betaMode=off", tristate.New(true), tristate.New(false), tristate.TriState{}, false},
+		{"Empty is None", "audit=&beta_mode=none", tristate.TriState{}, tristate.TriState{}, tristate.TriState{}, false},
+		{"Nested", "nested.audit=0", tristate.TriState{}, tristate.TriState{}, tristate.New(false), false},
+		{"Invalid", "audit=maybe", tristate.TriState{}, tristate.TriState{}, tristate.TriState{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			m := dynamicpb.NewMessage(md)
+			err := (&QueryParser{}).Parse(m, values, utilities.NewDoubleArray(nil))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			audit, _ := Get(m, "audit")
+			beta, _ := Get(m, "beta_mode")
+			var nested tristate.TriState
+			if n := m.Get(md.Fields().ByName("nested")).Message(); n.IsValid() {
+				nested, _ = Get(n.Interface(), "audit")
+			}
+			if audit != tt.audit || beta != tt.beta || nested != tt.nested {
+				t.Errorf("audit=%v beta=%v nested=%v", audit, beta, nested)
+			}
+		})
+	}
+}
+
+// TestProtojsonTranscoding checks that a Go struct with TriState fields
+// survives the JSON <-> proto transcoding grpc-gateway performs.
+func TestProtojsonTranscoding(t *testing.T) {
+	type settings struct {
+		Audit    tristate.TriState `json:"audit"`
+		BetaMode tristate.TriState `json:"betaMode"`
+	}
+	md := settingsDescriptor(t)
+	for _, in := range []settings{
+		{},
+		{Audit: tristate.New(true), BetaMode: tristate.New(false)},
+		{Audit: tristate.New(false)},
+	} {
+		body, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		m := dynamicpb.NewMessage(md)
+		if err := protojson.Unmarshal(body, m); err != nil {
+			t.Fatalf("protojson.Unmarshal(%s) failed: %v", body, err)
+		}
+		wire, err := protojson.Marshal(m)
+		if err != nil {
+			t.Fatalf("protojson.Marshal failed: %v", err)
+		}
+		var out settings
+		if err := json.Unmarshal(wire, &out); err != nil {
+			t.Fatalf("Unmarshal(%s) failed: %v", wire, err)
+		}
+		if out != in {
+			t.Errorf("%s transcoded to %s", body, wire)
+		}
+	}
+}
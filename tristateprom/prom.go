@@ -0,0 +1,99 @@
+// Package tristateprom exports tristate values as Prometheus metrics, so
+// dashboards can show which flags are explicitly set and which are
+// inherited.
+//
+// A Collector reports two gauges per registered value, labeled by name:
+// <namespace>_tristate_value is 1 for True and 0 otherwise, and
+// <namespace>_tristate_set is 1 unless the value is None:
+//
+//	c := tristateprom.NewCollector("myapp")
+//	c.Register("dark_mode", darkMode) // a *tristate.Observable
+//	prometheus.MustRegister(c)
+//
+// Values are read at scrape time.
+package tristateprom
+
+import (
+	"maps"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"tristate"
+)
+
+// Loader is implemented by the concurrency-safe tristate types, such as
+// Observable, Atomic, Versioned, and Expiring.
+type Loader interface {
+	Load() tristate.TriState
+}
+
+// LoaderFunc adapts a function to a Loader.
+type LoaderFunc func() tristate.TriState
+
+// Load calls f.
+func (f LoaderFunc) Load() tristate.TriState { return f() }
+
+// Collector is a prometheus.Collector for a set of named values. It is
+// safe for concurrent use.
+type Collector struct {
+	value, set *prometheus.Desc
+
+	mu      sync.RWMutex
+	loaders map[string]Loader
+}
+
+// NewCollector returns a Collector whose metric names start with
+// namespace, which may be empty.
+func NewCollector(namespace string) *Collector {
+	labels := []string{"name"}
+	return &Collector{
+		value: prometheus.NewDesc(prometheus.BuildFQName(namespace, "tristate", "value"),
+			"1 if the value is true, 0 if false or unset.", labels, nil),
+		set: prometheus.NewDesc(prometheus.BuildFQName(namespace, "tristate", "set"),
+			"1 if the value is set explicitly, 0 if unset and inherited.", labels, nil),
+		loaders: map[string]Loader{},
+	}
+}
+
+// Register adds l under name, replacing any Loader already registered
+// under it.
+func (c *Collector) Register(name string, l Loader) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loaders[name] = l
+}
+
+// Unregister removes the Loader registered under name.
+func (c *Collector) Unregister(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.loaders, name)
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.value
+	ch <- c.set
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	// Load outside the lock, so a slow Loader does not block Register.
+	c.mu.RLock()
+	loaders := maps.Clone(c.loaders)
+	c.mu.RUnlock()
+
+	for name, l := range loaders {
+		v := l.Load()
+		ch <- prometheus.MustNewConstMetric(c.value, prometheus.GaugeValue, gauge(v.IsTrue()), name)
+		ch <- prometheus.MustNewConstMetric(c.set, prometheus.GaugeValue, gauge(!v.IsNone()), name)
+	}
+}
+
+func gauge(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
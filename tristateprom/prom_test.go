@@ -0,0 +1,52 @@
+package tristateprom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"tristate"
+)
+
+func TestCollector(t *testing.T) {
+	dark := tristate.NewObservable(tristate.New(true))
+	beta := tristate.NewAtomic(tristate.New(false))
+	c := NewCollector("app")
+	c.Register("dark_mode", dark)
+	c.Register("beta", beta)
+	c.Register("legacy", LoaderFunc(func() tristate.TriState { return tristate.TriState{} }))
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+
+	want := `
+# HELP app_tristate_set 1 if the value is set explicitly, 0 if unset and inherited.
+# TYPE app_tristate_set gauge
+app_tristate_set{name="beta"} 1
+app_tristate_set{name="dark_mode"} 1
+app_tristate_set{name="legacy"} 0
+# HELP app_tristate_value 1 if the value is true, 0 if false or unset.
+# TYPE app_tristate_value gauge
+app_tristate_value{name="beta"} 0
+app_tristate_value{name="dark_mode"} 1
+app_tristate_value{name="legacy"} 0
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+
+	// Values are read at scrape time.
+	dark.Store(tristate.TriState{})
+	c.Unregister("legacy")
+	want = `
+# HELP app_tristate_set 1 if the value is set explicitly, 0 if unset and inherited.
+# TYPE app_tristate_set gauge
+app_tristate_set{name="beta"} 1
+app_tristate_set{name="dark_mode"} 0
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "app_tristate_set"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package tristaterapid provides pgregory.net/rapid generators for
+// tristate values, so property tests of merge and logic code need no
+// generator boilerplate:
+//
+//	rapid.Check(t, func(t *rapid.T) {
+//		a := tristaterapid.TriState().Draw(t, "a")
+//		b := tristaterapid.MostlyNone().Draw(t, "b")
+//		...
+//	})
+//
+// Generated values shrink towards None, then False.
+package tristaterapid
+
+import (
+	"pgregory.net/rapid"
+
+	"tristate"
+)
+
+// TriState returns a generator choosing None, False, and True with equal
+// probability.
+func TriState() *rapid.Generator[tristate.TriState] {
+	return Weighted(1, 1, 1)
+}
+
+// MostlyNone returns a generator producing None four times in five, as in
+// sparse override sets where most settings are left unset.
+func MostlyNone() *rapid.Generator[tristate.TriState] {
+	return Weighted(8, 1, 1)
+}
+
+// Weighted returns a generator choosing None, False, and True with
+// probabilities proportional to the given weights. It panics if a weight
+// is negative or all are zero.
+func Weighted(none, falseW, trueW int) *rapid.Generator[tristate.TriState] {
+	if none < 0 || falseW < 0 || trueW < 0 || none+falseW+trueW == 0 {
+		panic("tristaterapid: invalid weights")
+	}
+	return rapid.Custom(func(t *rapid.T) tristate.TriState {
+		switch n := rapid.IntRange(0, none+falseW+trueW-1).Draw(t, "n"); {
+		case n < none:
+			return tristate.TriState{}
+		case n < none+falseW:
+			return tristate.New(false)
+		default:
+			return tristate.New(true)
+		}
+	})
+}
+
+// Map returns a generator of Maps with short lowercase keys and values
+// drawn from values.
+func Map(values *rapid.Generator[tristate.TriState]) *rapid.Generator[tristate.Map] {
+	return rapid.Map(rapid.MapOf(rapid.StringMatching(`[a-z]{1,8}`), values), func(m map[string]tristate.TriState) tristate.Map {
+		return m
+	})
+}
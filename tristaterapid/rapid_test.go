@@ -0,0 +1,61 @@
+package tristaterapid
+
+import (
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"tristate"
+)
+
+func TestWeighted(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  *rapid.Generator[tristate.TriState]
+		want [3]bool // whether None, False, and True may appear
+	}{
+		{"Uniform", TriState(), [3]bool{true, true, true}},
+		{"MostlyNone", MostlyNone(), [3]bool{true, true, true}},
+		{"OnlyTrue", Weighted(0, 0, 1), [3]bool{false, false, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen [3]bool
+			rapid.Check(t, func(t *rapid.T) {
+				v := tt.gen.Draw(t, "v")
+				switch {
+				case v.IsNone():
+					seen[0] = true
+				case v.IsFalse():
+					seen[1] = true
+				default:
+					seen[2] = true
+				}
+			})
+			if seen != tt.want {
+				t.Errorf("states seen = %v, want %v", seen, tt.want)
+			}
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		a, b := Map(TriState()).Draw(t, "a"), Map(MostlyNone()).Draw(t, "b")
+		merged := a.MergeOverride(b)
+		for k, v := range b {
+			if !v.IsNone() && merged[k] != v {
+				t.Fatalf("MergeOverride lost %s=%v", k, v)
+			}
+		}
+	})
+}
+
+func TestWeighted_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Weighted(0, 0, 0) did not panic")
+		}
+	}()
+	Weighted(0, 0, 0)
+}
@@ -0,0 +1,125 @@
+package tristatetest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"tristate"
+)
+
+// FakeClock is a tristate.Clock that only moves when told to, so TTL,
+// history, and debounce behavior can be tested without sleeping:
+//
+//	clock := tristatetest.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+//	e := &tristate.Expiring{Clock: clock}
+//	e.Set(tristate.New(true), time.Hour)
+//	clock.Advance(time.Hour) // e now reads its Fallback
+//
+// Timers scheduled with AfterFunc fire when Advance or Set reaches their
+// deadline. Their functions run synchronously, in deadline order, before
+// Advance or Set returns, so their effects are visible to the caller. A
+// FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock reading t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to run once the clock reaches d from now.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) tristate.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, f: f}
+	t.schedule(d)
+	return t
+}
+
+// Advance moves the clock forward by d, or back if d is negative, firing
+// the timers that fall due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to t, firing the timers that fall due.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.set(t)
+}
+
+// set moves the clock to t and fires due timers one at a time, releasing
+// c.mu, which the caller holds, while each runs.
+func (c *FakeClock) set(t time.Time) {
+	c.now = t
+	for {
+		i := -1
+		for j, ft := range c.timers {
+			if !ft.when.After(c.now) && (i < 0 || ft.when.Before(c.timers[i].when)) {
+				i = j
+			}
+		}
+		if i < 0 {
+			c.mu.Unlock()
+			return
+		}
+		ft := c.timers[i]
+		c.timers = slices.Delete(c.timers, i, i+1)
+		c.mu.Unlock()
+		ft.f()
+		c.mu.Lock()
+	}
+}
+
+// fakeTimer is a timer of a FakeClock. It is pending while in c.timers.
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	f     func()
+}
+
+// schedule arms t for d from now. The caller holds t.clock.mu.
+func (t *fakeTimer) schedule(d time.Duration) {
+	t.when = t.clock.now.Add(d)
+	t.clock.timers = append(t.clock.timers, t)
+}
+
+// remove disarms t, reporting whether it was pending. The caller holds
+// t.clock.mu.
+func (t *fakeTimer) remove() bool {
+	i := slices.Index(t.clock.timers, t)
+	if i < 0 {
+		return false
+	}
+	t.clock.timers = slices.Delete(t.clock.timers, i, i+1)
+	return true
+}
+
+// Stop prevents t from firing, reporting whether it was pending.
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.remove()
+}
+
+// Reset reschedules t for d from the clock's current time, reporting
+// whether it was pending.
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	pending := t.remove()
+	t.schedule(d)
+	return pending
+}
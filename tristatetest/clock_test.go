@@ -0,0 +1,61 @@
+package tristatetest
+
+import (
+	"testing"
+	"time"
+
+	"tristate"
+)
+
+// Compile-time check that FakeClock satisfies tristate.Clock.
+var _ tristate.Clock = (*FakeClock)(nil)
+
+func TestFakeClock(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(t0)
+
+	e := &tristate.Expiring{Fallback: tristate.New(false), Clock: clock}
+	e.Set(tristate.New(true), time.Hour)
+	clock.Advance(59 * time.Minute)
+	AssertTrue(t, e.Load())
+	clock.Advance(time.Minute)
+	AssertFalse(t, e.Load())
+
+	v := &tristate.Versioned{Clock: clock}
+	v.Set(tristate.New(true), "alice", "")
+	clock.Set(t0.Add(24 * time.Hour))
+	v.Set(tristate.New(false), "bob", "")
+	AssertTrue(t, v.At(t0.Add(12*time.Hour)))
+	if h := v.History(); !h[1].Time.Equal(t0.Add(24 * time.Hour)) {
+		t.Errorf("revision stamped %v, want the fake time", h[1].Time)
+	}
+}
+
+func TestFakeClock_AfterFunc(t *testing.T) {
+	clock := NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	var fired []string
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, "b") })
+	a := clock.AfterFunc(time.Second, func() { fired = append(fired, "a") })
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+	if !stopped.Stop() || stopped.Stop() {
+		t.Error("Stop() did not report the timer pending exactly once")
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if len(fired) != 0 {
+		t.Fatalf("fired %v early", fired)
+	}
+	clock.Advance(2 * time.Second)
+	if len(fired) != 2 || fired[0] != "a" || fired[1] != "b" {
+		t.Fatalf("fired %v, want [a b]", fired)
+	}
+
+	// A fired timer can be rearmed.
+	if a.Reset(time.Second) {
+		t.Error("Reset() of a fired timer reported it pending")
+	}
+	clock.Advance(time.Second)
+	if len(fired) != 3 {
+		t.Errorf("fired %v after Reset", fired)
+	}
+}
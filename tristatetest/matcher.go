@@ -0,0 +1,67 @@
+package tristatetest
+
+import "tristate"
+
+// Matcher matches TriState arguments by state rather than by exact struct
+// equality. It implements gomock.Matcher, and its Func method adapts it
+// for testify's mock.MatchedBy:
+//
+//	mockStore.EXPECT().SetAudit(tristatetest.Resolved())
+//	testifyMock.On("SetAudit", mock.MatchedBy(tristatetest.Resolved().Func()))
+type Matcher struct {
+	match func(tristate.TriState) bool
+	desc  string
+}
+
+// MatchesState returns a Matcher accepting TriState values in state s.
+func MatchesState(s tristate.State) Matcher {
+	var desc string
+	switch s {
+	case tristate.True:
+		desc = "is true"
+	case tristate.False:
+		desc = "is false"
+	default:
+		desc = "is none"
+	}
+	return Matcher{
+		match: func(v tristate.TriState) bool {
+			switch s {
+			case tristate.True:
+				return v.IsTrue()
+			case tristate.False:
+				return v.IsFalse()
+			default:
+				return v.IsNone()
+			}
+		},
+		desc: desc,
+	}
+}
+
+// Resolved returns a Matcher accepting True and False but not None.
+func Resolved() Matcher {
+	return Matcher{
+		match: func(v tristate.TriState) bool { return !v.IsNone() },
+		desc:  "is true or false",
+	}
+}
+
+// Matches reports whether x is a TriState, or non-nil *TriState, that the
+// Matcher accepts.
+func (m Matcher) Matches(x any) bool {
+	switch v := x.(type) {
+	case tristate.TriState:
+		return m.match(v)
+	case *tristate.TriState:
+		return v != nil && m.match(*v)
+	default:
+		return false
+	}
+}
+
+// String describes the Matcher, as gomock requires.
+func (m Matcher) String() string { return m.desc }
+
+// Func returns the Matcher as a predicate for testify's mock.MatchedBy.
+func (m Matcher) Func() func(tristate.TriState) bool { return m.match }
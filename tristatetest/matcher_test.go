@@ -0,0 +1,54 @@
+package tristatetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/mock/gomock"
+
+	"tristate"
+)
+
+// Compile-time check that Matcher satisfies gomock.Matcher.
+var _ gomock.Matcher = Matcher{}
+
+func TestMatcher(t *testing.T) {
+	yes, no, none := tristate.New(true), tristate.New(false), tristate.TriState{}
+	tests := []struct {
+		name    string
+		m       Matcher
+		x       any
+		want    bool
+		wantStr string
+	}{
+		{"True", MatchesState(tristate.True), yes, true, "is true"},
+		{"True rejects false", MatchesState(tristate.True), no, false, "is true"},
+		{"False pointer", MatchesState(tristate.False), &no, true, "is false"},
+		{"None", MatchesState(tristate.None), none, true, "is none"},
+		{"Nil pointer", MatchesState(tristate.None), (*tristate.TriState)(nil), false, "is none"},
+		{"Other type", MatchesState(tristate.True), true, false, "is true"},
+		{"Resolved", Resolved(), no, true, "is true or false"},
+		{"Resolved rejects none", Resolved(), none, false, "is true or false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Matches(tt.x); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.x, got, tt.want)
+			}
+			if got := tt.m.String(); got != tt.wantStr {
+				t.Errorf("String() = %q, want %q", got, tt.wantStr)
+			}
+		})
+	}
+}
+
+type auditMock struct{ mock.Mock }
+
+func (m *auditMock) SetAudit(v tristate.TriState) { m.Called(v) }
+
+func TestMatcher_Testify(t *testing.T) {
+	m := &auditMock{}
+	m.On("SetAudit", mock.MatchedBy(Resolved().Func())).Return()
+	m.SetAudit(tristate.New(false))
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package tristatetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"tristate"
+)
+
+// Codec is one encoding exercised by RoundTrip. Marshal encodes the value
+// v points to; Unmarshal decodes data into the value v points to.
+type Codec struct {
+	Name      string
+	Marshal   func(v any) ([]byte, error)
+	Unmarshal func(data []byte, v any) error
+}
+
+// JSON is the encoding/json Codec.
+var JSON = Codec{
+	Name:      "json",
+	Marshal:   func(v any) ([]byte, error) { return json.Marshal(v) },
+	Unmarshal: json.Unmarshal,
+}
+
+// Text is the command-line Codec: String to encode and Set to decode, as
+// used by flag, pflag, and envconfig. It applies to types implementing
+// both methods.
+var Text = Codec{
+	Name: "text",
+	Marshal: func(v any) ([]byte, error) {
+		s, ok := v.(fmt.Stringer)
+		if !ok {
+			return nil, fmt.Errorf("%T does not implement fmt.Stringer", v)
+		}
+		return []byte(s.String()), nil
+	},
+	Unmarshal: func(data []byte, v any) error {
+		s, ok := v.(interface{ Set(string) error })
+		if !ok {
+			return fmt.Errorf("%T does not implement Set(string) error", v)
+		}
+		return s.Set(string(data))
+	},
+}
+
+// RoundTrip asserts that TriState survives every codec losslessly in all
+// three states. With no codecs it uses JSON and Text.
+func RoundTrip(t *testing.T, codecs ...Codec) {
+	t.Helper()
+	RoundTripOf(t, func(v tristate.TriState) tristate.TriState { return v }, codecs...)
+}
+
+// RoundTripOf asserts that values of a type embedding TriState survive
+// every codec losslessly. It builds one value per state with build,
+// encodes it, decodes the result into a zero T, and compares the two.
+// Codecs for encodings implemented downstream, such as YAML or SQL, can
+// be supplied alongside JSON and Text. With no codecs it uses JSON, plus
+// Text if *T has a Set method.
+func RoundTripOf[T comparable](t *testing.T, build func(tristate.TriState) T, codecs ...Codec) {
+	t.Helper()
+	if len(codecs) == 0 {
+		codecs = []Codec{JSON}
+		var zero T
+		if _, ok := any(&zero).(interface{ Set(string) error }); ok {
+			codecs = append(codecs, Text)
+		}
+	}
+	for _, c := range codecs {
+		for _, state := range []tristate.TriState{{}, tristate.New(false), tristate.New(true)} {
+			t.Run(c.Name+"/"+state.String(), func(t *testing.T) {
+				want := build(state)
+				data, err := c.Marshal(&want)
+				if err != nil {
+					t.Fatalf("marshal %v: %v", want, err)
+				}
+				var got T
+				if err := c.Unmarshal(data, &got); err != nil {
+					t.Fatalf("unmarshal %q: %v", data, err)
+				}
+				if got != want {
+					t.Errorf("round trip through %q: got %v, want %v", data, got, want)
+				}
+			})
+		}
+	}
+}
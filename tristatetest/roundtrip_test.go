@@ -0,0 +1,20 @@
+package tristatetest
+
+import (
+	"testing"
+
+	"tristate"
+)
+
+func TestRoundTrip(t *testing.T) {
+	RoundTrip(t)
+}
+
+func TestRoundTripOf(t *testing.T) {
+	type settings struct {
+		Name  string            `json:"name"`
+		Audit tristate.TriState `json:"audit"`
+	}
+	RoundTripOf(t, func(v tristate.TriState) settings { return settings{Name: "svc", Audit: v} })
+	RoundTripOf(t, func(v tristate.TriState) tristate.Policy { return tristate.Policy(v) }, JSON)
+}
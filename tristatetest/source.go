@@ -0,0 +1,86 @@
+package tristatetest
+
+import (
+	"slices"
+	"sync"
+
+	"tristate"
+)
+
+// Source is a programmable tristate.Source for testing Resolver
+// precedence without real environment variables or files. It answers each
+// key with the value or error programmed for it, None otherwise, and
+// records every lookup. A Source is safe for concurrent use.
+type Source struct {
+	name string
+
+	mu      sync.Mutex
+	values  tristate.Map
+	errs    map[string]error
+	lookups []string
+}
+
+// NewSource returns a Source called name answering with values.
+func NewSource(name string, values tristate.Map) *Source {
+	s := &Source{name: name, values: tristate.Map{}, errs: map[string]error{}}
+	for k, v := range values {
+		s.values[k] = v
+	}
+	return s
+}
+
+// Name returns the name given to NewSource.
+func (s *Source) Name() string { return s.name }
+
+// Lookup records key and returns its programmed error, if any, or value.
+func (s *Source) Lookup(key string) (tristate.TriState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookups = append(s.lookups, key)
+	if err := s.errs[key]; err != nil {
+		return tristate.TriState{}, err
+	}
+	return s.values[key], nil
+}
+
+// Set programs key to answer v, clearing any programmed error.
+func (s *Source) Set(key string, v tristate.TriState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = v
+	delete(s.errs, key)
+}
+
+// Fail programs key to answer err.
+func (s *Source) Fail(key string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs[key] = err
+}
+
+// Lookups returns the keys looked up so far, in order.
+func (s *Source) Lookups() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.lookups)
+}
+
+// Calls returns how many times key has been looked up.
+func (s *Source) Calls(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, k := range s.lookups {
+		if k == key {
+			n++
+		}
+	}
+	return n
+}
+
+// ResetLookups forgets the lookups recorded so far.
+func (s *Source) ResetLookups() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookups = nil
+}
@@ -0,0 +1,45 @@
+package tristatetest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"tristate"
+)
+
+// Compile-time check that Source satisfies tristate.Source.
+var _ tristate.Source = (*Source)(nil)
+
+func TestSource(t *testing.T) {
+	flags := NewSource("flags", tristate.Map{"audit": tristate.New(false)})
+	file := NewSource("file", tristate.Map{"audit": tristate.New(true), "beta": tristate.New(true)})
+	r := tristate.NewResolver(flags, file)
+
+	v, layer, err := r.Lookup("audit")
+	if err != nil || !v.IsFalse() || layer != "flags" {
+		t.Errorf("Lookup(audit) = %v, %q, %v", v, layer, err)
+	}
+	if file.Calls("audit") != 0 {
+		t.Error("lower-precedence source consulted after a higher one answered")
+	}
+	r.Lookup("beta")
+	if got, want := flags.Lookups(), []string{"audit", "beta"}; !slices.Equal(got, want) {
+		t.Errorf("Lookups() = %q, want %q", got, want)
+	}
+
+	down := errors.New("down")
+	flags.Fail("beta", down)
+	if _, _, err := r.Lookup("beta"); !errors.Is(err, down) {
+		t.Errorf("Lookup(beta) error = %v, want %v", err, down)
+	}
+	flags.Set("beta", tristate.New(false))
+	if v, layer, err := r.Lookup("beta"); err != nil || !v.IsFalse() || layer != "flags" {
+		t.Errorf("Lookup(beta) after Set = %v, %q, %v", v, layer, err)
+	}
+
+	flags.ResetLookups()
+	if len(flags.Lookups()) != 0 {
+		t.Errorf("Lookups() after reset = %q", flags.Lookups())
+	}
+}
@@ -0,0 +1,75 @@
+// Package tristatetest provides test assertions for tristate values, so
+// tests can state the expected value directly instead of unpacking
+// (val, ok) pairs:
+//
+//	tristatetest.AssertTrue(t, cfg.Audit)
+//	enabled := tristatetest.RequireResolved(t, cfg.DarkMode)
+//
+// Assert functions report a failure with t.Errorf and return whether the
+// assertion held; Require functions stop the test with t.Fatalf.
+package tristatetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"tristate"
+)
+
+// AssertTrue reports an error unless got is True.
+func AssertTrue(t testing.TB, got tristate.TriState) bool {
+	t.Helper()
+	return AssertEqual(t, tristate.New(true), got)
+}
+
+// AssertFalse reports an error unless got is False.
+func AssertFalse(t testing.TB, got tristate.TriState) bool {
+	t.Helper()
+	return AssertEqual(t, tristate.New(false), got)
+}
+
+// AssertNone reports an error unless got is None.
+func AssertNone(t testing.TB, got tristate.TriState) bool {
+	t.Helper()
+	return AssertEqual(t, tristate.TriState{}, got)
+}
+
+// AssertEqual reports an error unless got equals want.
+func AssertEqual(t testing.TB, want, got tristate.TriState) bool {
+	t.Helper()
+	if got != want {
+		t.Errorf("tristate: got %v, want %v", got, want)
+		return false
+	}
+	return true
+}
+
+// AssertMapEqual reports an error listing every key whose value differs
+// between want and got. As elsewhere in tristate, a missing key equals
+// None.
+func AssertMapEqual(t testing.TB, want, got tristate.Map) bool {
+	t.Helper()
+	changes := tristate.Diff(want, got)
+	if len(changes) == 0 {
+		return true
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "tristate.Map mismatch (-want +got):")
+	for _, c := range changes {
+		fmt.Fprintf(&b, "\n\t%s: -%v +%v", c.Key, c.Before, c.After)
+	}
+	t.Error(b.String())
+	return false
+}
+
+// RequireResolved stops the test unless got is True or False, and returns
+// its bool value.
+func RequireResolved(t testing.TB, got tristate.TriState) bool {
+	t.Helper()
+	b, ok := got.Bool()
+	if !ok {
+		t.Fatalf("tristate: got none, want true or false")
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+package tristatetest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"tristate"
+)
+
+// recorder captures failures reported through testing.TB.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...any) { r.errors = append(r.errors, fmt.Sprint(args...)) }
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+}
+
+func TestAssert(t *testing.T) {
+	yes, no, none := tristate.New(true), tristate.New(false), tristate.TriState{}
+	tests := []struct {
+		name    string
+		assert  func(testing.TB) bool
+		wantMsg string // "" for success
+	}{
+		{"True", func(t testing.TB) bool { return AssertTrue(t, yes) }, ""},
+		{"True fails", func(t testing.TB) bool { return AssertTrue(t, none) }, "tristate: got none, want true"},
+		{"False", func(t testing.TB) bool { return AssertFalse(t, no) }, ""},
+		{"False fails", func(t testing.TB) bool { return AssertFalse(t, yes) }, "tristate: got true, want false"},
+		{"None", func(t testing.TB) bool { return AssertNone(t, none) }, ""},
+		{"None fails", func(t testing.TB) bool { return AssertNone(t, no) }, "tristate: got false, want none"},
+		{"Equal", func(t testing.TB) bool { return AssertEqual(t, no, no) }, ""},
+		{"Map", func(t testing.TB) bool {
+			return AssertMapEqual(t, tristate.Map{"a": yes, "b": none}, tristate.Map{"a": yes})
+		}, ""},
+		{"Map fails", func(t testing.TB) bool {
+			return AssertMapEqual(t, tristate.Map{"a": yes, "b": no}, tristate.Map{"a": no, "c": yes})
+		}, "tristate.Map mismatch (-want +got):\n\ta: -true +false\n\tb: -false +none\n\tc: -none +true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ok := tt.assert(r)
+			if ok != (tt.wantMsg == "") {
+				t.Errorf("returned %v", ok)
+			}
+			if got := strings.Join(r.errors, "\n"); got != tt.wantMsg {
+				t.Errorf("reported %q, want %q", got, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRequireResolved(t *testing.T) {
+	r := &recorder{TB: t}
+	if !RequireResolved(r, tristate.New(true)) || r.fatal {
+		t.Error("RequireResolved(true) failed")
+	}
+	RequireResolved(r, tristate.TriState{})
+	if !r.fatal {
+		t.Error("RequireResolved(none) did not stop the test")
+	}
+}
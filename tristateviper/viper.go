@@ -0,0 +1,39 @@
+// Package tristateviper lets spf13/viper decode configuration into
+// tristate.TriState fields.
+//
+// Without a hook, viper's underlying mapstructure decoder cannot populate
+// TriState and every such field is left as None. With it, booleans and
+// boolean strings decode to True or False, and keys missing from every
+// configuration source stay None:
+//
+//	var cfg struct {
+//		Beta tristate.TriState `mapstructure:"beta"`
+//	}
+//	err := viper.Unmarshal(&cfg, tristateviper.DecoderOption())
+package tristateviper
+
+import (
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+
+	"tristate"
+)
+
+// Hook returns tristate.DecodeHook as a mapstructure.DecodeHookFuncType,
+// converting bools, boolean strings, 0/1, and nil into TriState.
+func Hook() mapstructure.DecodeHookFuncType {
+	return tristate.DecodeHook()
+}
+
+// DecoderOption returns a viper.DecoderConfigOption that installs Hook
+// ahead of viper's default decode hooks, for use with viper.Unmarshal and
+// viper.UnmarshalKey.
+func DecoderOption() viper.DecoderConfigOption {
+	return func(c *mapstructure.DecoderConfig) {
+		if c.DecodeHook == nil {
+			c.DecodeHook = Hook()
+			return
+		}
+		c.DecodeHook = mapstructure.ComposeDecodeHookFunc(Hook(), c.DecodeHook)
+	}
+}
@@ -0,0 +1,78 @@
+package tristateviper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"tristate"
+)
+
+type config struct {
+	Audit   tristate.TriState `mapstructure:"audit"`
+	Beta    tristate.TriState `mapstructure:"beta"`
+	Tracing tristate.TriState `mapstructure:"tracing"`
+	Missing tristate.TriState `mapstructure:"missing"`
+	Name    string            `mapstructure:"name"`
+	Flags   tristate.Map      `mapstructure:"flags"`
+}
+
+func TestDecoderOption(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	err := v.ReadConfig(strings.NewReader(`
+audit: true
+beta: "false"
+name: svc
+flags:
+  dark_mode: true
+  legacy: "0"
+`))
+	if err != nil {
+		t.Fatalf("ReadConfig failed: %v", err)
+	}
+	v.Set("tracing", "1")
+
+	var cfg config
+	if err := v.Unmarshal(&cfg, DecoderOption()); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  tristate.TriState
+		want bool
+		set  bool
+	}{
+		{"Bool", cfg.Audit, true, true},
+		{"String", cfg.Beta, false, true},
+		{"Override", cfg.Tracing, true, true},
+		{"Missing key", cfg.Missing, false, false},
+		{"Map entry", cfg.Flags["dark_mode"], true, true},
+		{"Map string entry", cfg.Flags["legacy"], false, true},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.got.Bool(); got != tt.want || ok != tt.set {
+			t.Errorf("%s: Bool() = (%v, %v), want (%v, %v)", tt.name, got, ok, tt.want, tt.set)
+		}
+	}
+	if cfg.Name != "svc" {
+		t.Errorf("Name = %q, want svc", cfg.Name)
+	}
+}
+
+func TestHook_InvalidInput(t *testing.T) {
+	v := viper.New()
+	v.Set("audit", "sometimes")
+	var cfg config
+	if err := v.Unmarshal(&cfg, DecoderOption()); err == nil {
+		t.Error("Unmarshal accepted an invalid value")
+	}
+
+	v = viper.New()
+	v.Set("audit", []string{"x"})
+	if err := v.Unmarshal(&cfg, DecoderOption()); err == nil {
+		t.Error("Unmarshal accepted a slice value")
+	}
+}
@@ -0,0 +1,52 @@
+package tristate
+
+// TriValue is a layered configuration value of any comparable type,
+// distinguishing Unset from an explicit zero value (such as a timeout of
+// 0 or an empty string) and from an explicit non-zero value. It embeds
+// Optional, so it has the same accessors and codecs; its zero value is
+// Unset. MergeStructs layers TriValue fields like TriState ones.
+type TriValue[T comparable] struct {
+	Optional[T]
+}
+
+// Explicit returns a TriValue set to v, which may be the zero value.
+func Explicit[T comparable](v T) TriValue[T] {
+	return TriValue[T]{Of(v)}
+}
+
+// IsExplicitZero reports whether v is set to the zero value of T.
+func (v TriValue[T]) IsExplicitZero() bool {
+	var zero T
+	return v.set && v.value == zero
+}
+
+// State classifies v as a TriState: None when unset, False when set to
+// the zero value, and True when set to any other value.
+func (v TriValue[T]) State() TriState {
+	switch {
+	case !v.set:
+		return TriState{}
+	case v.IsExplicitZero():
+		return New(false)
+	default:
+		return New(true)
+	}
+}
+
+// Override returns src if it is set, otherwise v, matching
+// Map.MergeOverride: an explicit zero in src wins, while Unset never does.
+func (v TriValue[T]) Override(src TriValue[T]) TriValue[T] {
+	if src.set {
+		return src
+	}
+	return v
+}
+
+// Fallback returns v if it is set, otherwise src, matching
+// Map.MergeMonotone: a value once set is never revised.
+func (v TriValue[T]) Fallback(src TriValue[T]) TriValue[T] {
+	if v.set {
+		return v
+	}
+	return src
+}
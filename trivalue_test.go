@@ -0,0 +1,82 @@
+package tristate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTriValue(t *testing.T) {
+	var unset TriValue[time.Duration]
+	zero, five := Explicit(time.Duration(0)), Explicit(5*time.Second)
+
+	tests := []struct {
+		name     string
+		v        TriValue[time.Duration]
+		wantZero bool
+		want     State
+	}{
+		{"Unset", unset, false, None},
+		{"Explicit zero", zero, true, False},
+		{"Explicit value", five, false, True},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v.IsExplicitZero(); got != tt.wantZero {
+				t.Errorf("IsExplicitZero() = %v, want %v", got, tt.wantZero)
+			}
+			if got := tt.v.State().value; got != tt.want {
+				t.Errorf("State() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := five.Override(zero); got != zero {
+		t.Errorf("Override(explicit zero) = %v, want the explicit zero", got)
+	}
+	if got := five.Override(unset); got != five {
+		t.Errorf("Override(unset) = %v, want the receiver", got)
+	}
+	if got := unset.Fallback(zero); got != zero {
+		t.Errorf("unset.Fallback(zero) = %v", got)
+	}
+	if got := five.Fallback(zero); got != five {
+		t.Errorf("Fallback kept %v, want the receiver", got)
+	}
+	if got := zero.ValueOr(time.Minute); got != 0 {
+		t.Errorf("ValueOr() = %v, want the explicit zero", got)
+	}
+}
+
+func TestTriValue_JSON(t *testing.T) {
+	var v struct {
+		Name TriValue[string] `json:"name"`
+		Mode TriValue[string] `json:"mode"`
+	}
+	if err := json.Unmarshal([]byte(`{"name": "", "mode": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Name.IsExplicitZero() || v.Mode.IsSet() {
+		t.Errorf("decoded %+v", v)
+	}
+	if out, _ := json.Marshal(v); string(out) != `{"name":"","mode":null}` {
+		t.Errorf("Marshal() = %s", out)
+	}
+}
+
+func TestMergeStructs_Optional(t *testing.T) {
+	type layer struct {
+		Timeout TriValue[time.Duration]
+		Region  Optional[string]
+		Audit   TriState
+	}
+	dst := layer{Timeout: Explicit(time.Second), Region: Of("eu"), Audit: New(true)}
+	src := layer{Timeout: Explicit(time.Duration(0))}
+	if err := MergeStructs(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	want := layer{Timeout: Explicit(time.Duration(0)), Region: Of("eu"), Audit: New(true)}
+	if dst != want {
+		t.Errorf("MergeStructs() = %+v, want %+v", dst, want)
+	}
+}
@@ -0,0 +1,49 @@
+package tristate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ValidateStruct reports every TriState or Traced field of the struct v (or
+// pointer to one) that is tagged `tristate:"required"` but is None. Nested
+// structs, embedded structs, and non-nil struct pointers are checked too.
+// The result joins one error per missing field, each wrapping ErrRequired
+// and naming the field's Go path, or is nil when every required field is
+// set.
+func ValidateStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("tristate: ValidateStruct requires a struct, got %T", v)
+	}
+
+	var errs []error
+	err := walkFields(addressable(rv), "", func(field reflect.Value, sf reflect.StructField, path string) error {
+		opts, err := parseTag(sf.Tag.Get(tagName))
+		if err != nil {
+			return fmt.Errorf("tristate: field %s: %w", path, err)
+		}
+		if !opts.required {
+			return nil
+		}
+		var value TriState
+		switch cur := field.Interface().(type) {
+		case TriState:
+			value = cur
+		case Traced:
+			value = cur.Value
+		}
+		if err := value.Required(); err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", path, err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
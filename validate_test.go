@@ -0,0 +1,60 @@
+package tristate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type validateDB struct {
+	TLS TriState `tristate:"required"`
+}
+
+type validateConfig struct {
+	Audit    TriState `tristate:"required"`
+	Beta     TriState `tristate:"required,default=false"`
+	Optional TriState
+	Origin   Traced `tristate:"required"`
+	DB       validateDB
+}
+
+func TestTriState_Required(t *testing.T) {
+	if err := (TriState{}).Required(); !errors.Is(err, ErrRequired) {
+		t.Errorf("None.Required() = %v, want ErrRequired", err)
+	}
+	if err := New(false).Required(); err != nil {
+		t.Errorf("False.Required() = %v, want nil", err)
+	}
+}
+
+func TestValidateStruct(t *testing.T) {
+	var cfg validateConfig
+	err := ValidateStruct(&cfg)
+	if !errors.Is(err, ErrRequired) {
+		t.Fatalf("ValidateStruct error = %v, want ErrRequired", err)
+	}
+	for _, field := range []string{"Audit", "Beta", "Origin", "DB.TLS"} {
+		if !strings.Contains(err.Error(), "field "+field+":") {
+			t.Errorf("error %q does not mention %s", err, field)
+		}
+	}
+	if strings.Contains(err.Error(), "Optional") {
+		t.Errorf("error %q mentions an optional field", err)
+	}
+
+	cfg = validateConfig{
+		Audit:  New(true),
+		Origin: Traced{Value: New(false)},
+		DB:     validateDB{TLS: New(true)},
+	}
+	if err := ApplyDefaults(&cfg); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+	if err := ValidateStruct(cfg); err != nil {
+		t.Errorf("ValidateStruct after defaults = %v, want nil", err)
+	}
+
+	if err := ValidateStruct(42); err == nil || errors.Is(err, ErrRequired) {
+		t.Errorf("ValidateStruct(42) = %v, want type error", err)
+	}
+}
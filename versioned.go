@@ -0,0 +1,111 @@
+package tristate
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Revision records one transition of a Versioned value.
+type Revision struct {
+	Value  TriState  `json:"value"`
+	Time   time.Time `json:"time"`
+	Actor  string    `json:"actor,omitempty"`  // who made the change
+	Reason string    `json:"reason,omitempty"` // why, e.g. an incident ID
+	Undo   bool      `json:"undo,omitempty"`   // whether the change was an Undo
+}
+
+// Versioned is a TriState that keeps the full history of its transitions,
+// answering "when was this disabled, and by whom". History is append-only:
+// Undo records a new revision rather than erasing one. A Versioned is safe
+// for concurrent use; the zero value holds None with no history.
+type Versioned struct {
+	// Clock stamps revisions; nil means SystemClock. It must not be
+	// changed once the Versioned is in use.
+	Clock Clock
+
+	mu        sync.RWMutex
+	revisions []Revision
+	live      []int // indexes of revisions not yet undone, oldest first
+}
+
+// Load returns the current value.
+func (v *Versioned) Load() TriState {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.current()
+}
+
+// Set changes the value to val, recording actor and reason. It reports
+// whether a revision was recorded, which is not the case if val equals
+// the current value.
+func (v *Versioned) Set(val TriState, actor, reason string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if val == v.current() {
+		return false
+	}
+	v.live = append(v.live, len(v.revisions))
+	v.append(Revision{Value: val, Actor: actor, Reason: reason})
+	return true
+}
+
+// Undo reverts the latest change not already undone, restoring the value
+// before it, and records that as a revision by actor. Repeated calls step
+// further back. It returns the recorded revision, or false if there is
+// nothing left to undo.
+func (v *Versioned) Undo(actor, reason string) (Revision, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.live) == 0 {
+		return Revision{}, false
+	}
+	v.live = v.live[:len(v.live)-1]
+	return v.append(Revision{Value: v.stackTop(), Actor: actor, Reason: reason, Undo: true}), true
+}
+
+// History returns a copy of every revision, oldest first.
+func (v *Versioned) History() []Revision {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return slices.Clone(v.revisions)
+}
+
+// At returns the value in effect at time t: that of the latest revision
+// made at or before t, or None if t predates the history.
+func (v *Versioned) At(t time.Time) TriState {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	i := sort.Search(len(v.revisions), func(i int) bool { return v.revisions[i].Time.After(t) })
+	if i == 0 {
+		return TriState{}
+	}
+	return v.revisions[i-1].Value
+}
+
+func (v *Versioned) current() TriState {
+	if len(v.revisions) == 0 {
+		return TriState{}
+	}
+	return v.revisions[len(v.revisions)-1].Value
+}
+
+// stackTop returns the value of the latest live revision, or None.
+func (v *Versioned) stackTop() TriState {
+	if len(v.live) == 0 {
+		return TriState{}
+	}
+	return v.revisions[v.live[len(v.live)-1]].Value
+}
+
+// append stamps r and adds it to the history. Timestamps never go
+// backwards, keeping the history sorted for At even if the clock does.
+func (v *Versioned) append(r Revision) Revision {
+	r.Time = now(v.Clock)
+	if n := len(v.revisions); n > 0 && r.Time.Before(v.revisions[n-1].Time) {
+		r.Time = v.revisions[n-1].Time
+	}
+	v.revisions = append(v.revisions, r)
+	return r
+}
@@ -0,0 +1,94 @@
+package tristate
+
+import (
+	"testing"
+	"time"
+)
+
+// stepClock returns a clock advancing one minute per call from t0.
+func stepClock(t0 time.Time) Clock {
+	n := 0
+	return ClockFunc(func() time.Time {
+		n++
+		return t0.Add(time.Duration(n) * time.Minute)
+	})
+}
+
+func TestVersioned(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	v := &Versioned{Clock: stepClock(t0)}
+
+	if !v.Load().IsNone() || len(v.History()) != 0 {
+		t.Fatal("zero Versioned is not empty")
+	}
+	v.Set(New(true), "alice", "launch") // t0+1m
+	if v.Set(New(true), "bob", "") {    // no change
+		t.Error("Set recorded an unchanged value")
+	}
+	v.Set(New(false), "bob", "INC-42") // t0+2m
+
+	h := v.History()
+	if len(h) != 2 || h[1].Actor != "bob" || h[1].Reason != "INC-42" || !h[1].Time.Equal(t0.Add(2*time.Minute)) {
+		t.Fatalf("History() = %+v", h)
+	}
+	h[0].Actor = "mallory"
+	if v.History()[0].Actor != "alice" {
+		t.Error("History() returned shared storage")
+	}
+
+	tests := []struct {
+		at   time.Duration
+		want State
+	}{
+		{0, None},
+		{time.Minute, True},
+		{90 * time.Second, True},
+		{2 * time.Minute, False},
+		{time.Hour, False},
+	}
+	for _, tt := range tests {
+		if got := v.At(t0.Add(tt.at)); got.value != tt.want {
+			t.Errorf("At(t0+%v) = %v, want %v", tt.at, got.value, tt.want)
+		}
+	}
+}
+
+func TestVersioned_Undo(t *testing.T) {
+	v := &Versioned{Clock: stepClock(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))}
+	v.Set(New(true), "alice", "")
+	v.Set(New(false), "bob", "")
+
+	r, ok := v.Undo("carol", "revert INC-42")
+	if !ok || !r.Undo || r.Actor != "carol" || !r.Value.IsTrue() || !v.Load().IsTrue() {
+		t.Fatalf("first Undo() = %+v, %v; Load() = %v", r, ok, v.Load())
+	}
+	if r, ok = v.Undo("carol", ""); !ok || !r.Value.IsNone() {
+		t.Fatalf("second Undo() = %+v, %v", r, ok)
+	}
+	if _, ok := v.Undo("carol", ""); ok {
+		t.Error("Undo succeeded with nothing left to undo")
+	}
+	if n := len(v.History()); n != 4 {
+		t.Errorf("history has %d revisions, want 4", n)
+	}
+
+	// A Set after an Undo is itself undoable.
+	v.Set(New(false), "dave", "")
+	if r, _ := v.Undo("dave", ""); !r.Value.IsNone() {
+		t.Errorf("Undo after Set restored %v, want None", r.Value)
+	}
+}
+
+func TestVersioned_ClockSkew(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{t0.Add(time.Hour), t0}
+	v := &Versioned{Clock: ClockFunc(func() time.Time { tm := times[0]; times = times[1:]; return tm })}
+	v.Set(New(true), "", "")
+	v.Set(New(false), "", "")
+	if h := v.History(); h[1].Time.Before(h[0].Time) {
+		t.Errorf("history went backwards: %v then %v", h[0].Time, h[1].Time)
+	}
+	if got := v.At(t0.Add(time.Hour)); !got.IsFalse() {
+		t.Errorf("At() = %v, want the latest revision at that time", got)
+	}
+}